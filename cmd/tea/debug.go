@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/debugger"
+	"github.com/tealang/core/pkg/repl"
+	"gopkg.in/urfave/cli.v1"
+)
+
+const debugHelp = `Commands:
+  break <line>, b     set a breakpoint
+  clear <line>        remove a breakpoint
+  breakpoints         list all breakpoints
+  continue, c         run until the next breakpoint
+  step, s             step to the next statement, entering function calls
+  next, n             step to the next statement of the current function
+  out, o              step out of the current function
+  where, w            show the current statement
+  stack, bt           show the call stack
+  scopes, v           list the variables of each namespace level
+  behavior            show the context behavior
+  print <expr>, p     evaluate an expression in the current namespace
+  quit, q             abort the execution`
+
+// debugPrompt is an interactive debugger command prompt.
+type debugPrompt struct {
+	reader *bufio.Reader
+	lines  []string
+	dbg    *debugger.Debugger
+}
+
+// pause reads debugger commands until the execution is resumed.
+func (p *debugPrompt) pause(s debugger.State) (debugger.Mode, error) {
+	fmt.Fprintf(os.Stdout, "paused (%s) at line %d\n", s.Reason, s.Line)
+	if s.Line <= len(p.lines) {
+		fmt.Fprintf(os.Stdout, "%4d  %s\n", s.Line, p.lines[s.Line-1])
+	}
+	for {
+		fmt.Fprint(os.Stdout, "(debug) ")
+		input, err := p.reader.ReadString('\n')
+		if err == io.EOF {
+			return debugger.ModeContinue, debugger.ErrAborted
+		} else if err != nil {
+			return debugger.ModeContinue, err
+		}
+		fields := strings.Fields(input)
+		if len(fields) == 0 {
+			continue
+		}
+		args := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input), fields[0]))
+		switch fields[0] {
+		case "break", "b", "clear":
+			line, err := strconv.Atoi(args)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "expected line number")
+			} else if fields[0] == "clear" {
+				p.dbg.Clear(line)
+			} else {
+				p.dbg.Break(line)
+			}
+		case "breakpoints":
+			for _, line := range p.dbg.Breakpoints() {
+				fmt.Fprintf(os.Stdout, "line %d\n", line)
+			}
+		case "continue", "c":
+			return debugger.ModeContinue, nil
+		case "step", "s":
+			return debugger.ModeStepInto, nil
+		case "next", "n":
+			return debugger.ModeStepOver, nil
+		case "out", "o":
+			return debugger.ModeStepOut, nil
+		case "where", "w":
+			fmt.Fprintf(os.Stdout, "line %d, column %d: %s\n", s.Line, s.Column, s.Statement.Source())
+		case "stack", "bt":
+			for _, frame := range debugger.Frames(s) {
+				if frame.Signature != nil {
+					fmt.Fprintf(os.Stdout, "%s%s at line %d\n", frame.Function, frame.Signature, frame.Line)
+				} else {
+					fmt.Fprintf(os.Stdout, "%s at line %d\n", frame.Function, frame.Line)
+				}
+			}
+		case "scopes", "v":
+			for i, scope := range debugger.Scopes(s.Context, s.Context.Namespace) {
+				if scope.Global {
+					fmt.Fprintln(os.Stdout, "global:")
+				} else {
+					fmt.Fprintf(os.Stdout, "level %d:\n", i)
+				}
+				for _, value := range scope.Values {
+					fmt.Fprintf(os.Stdout, "  %s = %s\n", value.VariableString(), value)
+				}
+			}
+		case "behavior":
+			fmt.Fprintln(os.Stdout, s.Context.Behavior)
+		case "print", "p":
+			value, err := debugger.Evaluate(s.Context, s.Context.Namespace, args)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			} else {
+				fmt.Fprintln(os.Stdout, value)
+			}
+		case "quit", "q":
+			return debugger.ModeContinue, debugger.ErrAborted
+		case "help", "h":
+			fmt.Fprintln(os.Stdout, debugHelp)
+		default:
+			fmt.Fprintf(os.Stderr, "unknown command %s, try help\n", fields[0])
+		}
+	}
+}
+
+func debugProgramFile(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return errors.New("required filename")
+	}
+	code, err := ioutil.ReadFile(c.Args()[0])
+	if err != nil {
+		return err
+	}
+	breakpoints := c.IntSlice("break")
+	p := &debugPrompt{
+		reader: bufio.NewReader(os.Stdin),
+		lines:  strings.Split(string(code), "\n"),
+	}
+	p.dbg = debugger.New(p.pause, len(breakpoints) == 0)
+	for _, line := range breakpoints {
+		p.dbg.Break(line)
+	}
+	cfg := newConfig(c)
+	cfg.Hook = p.dbg
+	cfg.Args = programArgs(c)
+	if _, err = repl.New(cfg).Interpret(string(code)); errors.Cause(err) == debugger.ErrAborted {
+		return nil
+	}
+	return exitError(err)
+}
@@ -5,19 +5,75 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 
+	"github.com/tealang/core/pkg/dap"
+	"github.com/tealang/core/pkg/linter"
+	"github.com/tealang/core/pkg/lsp"
+	"github.com/tealang/core/pkg/profiler"
 	"github.com/tealang/core/pkg/repl"
+	"github.com/tealang/core/pkg/runtime"
 	"gopkg.in/urfave/cli.v1"
 )
 
 var (
 	interactiveMode, graphvizMode bool
+	overflowMode                  runtime.OverflowMode
 )
 
+// exitError maps the exit request of the program to the process exit status.
+func exitError(err error) error {
+	if code, ok := runtime.ExitStatus(err); ok {
+		if code == 0 {
+			return nil
+		}
+		return cli.NewExitError("", code)
+	}
+	return err
+}
+
+// programArgs returns the arguments following the program file, an optional "--" separator is removed.
+func programArgs(c *cli.Context) []string {
+	args := c.Args().Tail()
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	return args
+}
+
+// printError reports the error including the stack trace of the program if available.
+func printError(err error) {
+	fmt.Fprint(os.Stderr, runtime.Report(err))
+}
+
+func newConfig(c *cli.Context) repl.Config {
+	var system runtime.System
+	if c.GlobalBool("sandbox") || c.GlobalString("root") != "" {
+		sandbox := &runtime.Sandbox{In: os.Stdin, Out: os.Stdout, Err: os.Stderr, AllowExec: c.GlobalBool("allow-exec")}
+		if root := c.GlobalString("root"); root != "" {
+			sandbox.FS = runtime.Dir(root)
+		}
+		system = sandbox
+	}
+	return repl.Config{
+		OutputGraph:  c.GlobalBool("graph"),
+		OutputSource: c.GlobalBool("source"),
+		Optimize:     c.GlobalBool("optimize"),
+		Limits: runtime.Limits{
+			MaxSteps: c.GlobalInt("max-steps"),
+			MaxDepth: c.GlobalInt("max-depth"),
+			MaxSize:  c.GlobalInt("max-size"),
+			Timeout:  c.GlobalDuration("timeout"),
+		},
+		Overflow: overflowMode,
+		System:   system,
+	}
+}
+
 func runInteractiveShell(c *cli.Context) error {
-	env := repl.New(repl.Config{OutputGraph: c.GlobalBool("graph")})
+	env := repl.New(newConfig(c))
 	reader := bufio.NewReader(os.Stdin)
 	for env.Active {
 		fmt.Fprint(os.Stdout, "> ")
@@ -26,8 +82,11 @@ func runInteractiveShell(c *cli.Context) error {
 			env.Stop()
 		} else {
 			output, err := env.Interpret(strings.TrimRight(input, "\n"))
+			if _, ok := runtime.ExitStatus(err); ok {
+				return exitError(err)
+			}
 			if err != nil {
-				fmt.Fprintln(os.Stderr, err)
+				printError(err)
 			} else if output != "" {
 				fmt.Fprintln(os.Stdout, output)
 			}
@@ -41,7 +100,104 @@ func executeProgramFile(c *cli.Context) error {
 	if c.NArg() < 1 {
 		return errors.New("required filename")
 	}
-	return repl.New(repl.Config{OutputGraph: c.GlobalBool("graph")}).Load(c.Args()[0])
+	cfg := newConfig(c)
+	cfg.Args = programArgs(c)
+	var (
+		prof    *profiler.Profiler
+		tracers profiler.Tracers
+	)
+	if c.String("profile") != "" {
+		prof = profiler.New()
+		tracers = append(tracers, prof)
+	}
+	if c.Bool("trace") {
+		tracers = append(tracers, profiler.NewLogger(os.Stderr))
+	}
+	if len(tracers) > 0 {
+		cfg.Tracer = tracers
+	}
+	err := repl.New(cfg).Load(c.Args()[0])
+	if prof != nil {
+		prof.Report(os.Stderr, c.Int("top"))
+		file, ferr := os.Create(c.String("profile"))
+		if ferr != nil {
+			return ferr
+		}
+		defer file.Close()
+		if ferr := prof.WriteProfile(file, c.Args()[0]); ferr != nil {
+			return ferr
+		}
+	}
+	return exitError(err)
+}
+
+func runLanguageServer(c *cli.Context) error {
+	return lsp.NewServer(os.Stdin, os.Stdout).Serve()
+}
+
+// runDebugAdapter serves the debug adapter protocol on stdio.
+// The program reads no input and its output is forwarded as output events, so it can not interfere with the protocol.
+func runDebugAdapter(c *cli.Context) error {
+	server := dap.NewServer(os.Stdin, os.Stdout)
+	sandbox := &runtime.Sandbox{
+		Out:       server.Output("stdout"),
+		Err:       server.Output("stderr"),
+		FS:        runtime.Dir(""),
+		Env:       environment(),
+		AllowExec: true,
+	}
+	if c.GlobalBool("sandbox") || c.GlobalString("root") != "" {
+		sandbox.FS, sandbox.Env, sandbox.AllowExec = nil, nil, c.GlobalBool("allow-exec")
+		if root := c.GlobalString("root"); root != "" {
+			sandbox.FS = runtime.Dir(root)
+		}
+	}
+	server.System = sandbox
+	return server.Serve()
+}
+
+// environment returns a copy of the environment of the process.
+func environment() map[string]string {
+	env := make(map[string]string)
+	for _, item := range os.Environ() {
+		if i := strings.Index(item, "="); i > 0 {
+			env[item[:i]] = item[i+1:]
+		}
+	}
+	return env
+}
+
+func lintProgramFiles(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return errors.New("required filename")
+	}
+	cfg := linter.DefaultConfig()
+	if file := c.String("config"); file != "" {
+		loaded, err := linter.LoadConfig(file)
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+	}
+	found := false
+	for _, file := range c.Args() {
+		code, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		diagnostics, err := linter.Lint(string(code), cfg)
+		if err != nil {
+			return fmt.Errorf("%s: %s", file, err)
+		}
+		for _, d := range diagnostics {
+			fmt.Fprintf(os.Stdout, "%s:%s\n", file, d)
+		}
+		found = found || len(diagnostics) > 0
+	}
+	if found {
+		return cli.NewExitError("", 1)
+	}
+	return nil
 }
 
 func main() {
@@ -57,6 +213,49 @@ func main() {
 			Usage:  "Show graphviz output instead of program result",
 			Hidden: false,
 		},
+		cli.BoolFlag{
+			Name:   "source",
+			Usage:  "Show canonical source code instead of program result",
+			Hidden: false,
+		},
+		cli.BoolFlag{
+			Name:   "optimize",
+			Usage:  "Fold constant terms and eliminate dead code before execution",
+			Hidden: false,
+		},
+		cli.IntFlag{
+			Name:  "max-steps",
+			Usage: "Limit the number of evaluation steps, 0 for no limit",
+		},
+		cli.IntFlag{
+			Name:  "max-depth",
+			Usage: "Limit the depth of nested function calls, 0 for no limit",
+		},
+		cli.IntFlag{
+			Name:  "max-size",
+			Usage: "Limit the size of strings, arrays and maps including their contents, 0 for no limit",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Limit the execution time of each input, 0 for no limit",
+		},
+		cli.GenericFlag{
+			Name:  "overflow",
+			Value: &overflowMode,
+			Usage: "Handle integer overflows by the mode check, wrap or saturate",
+		},
+		cli.BoolFlag{
+			Name:  "sandbox",
+			Usage: "Deny file access and isolate the environment variables of the program",
+		},
+		cli.StringFlag{
+			Name:  "root",
+			Usage: "Run sandboxed with file access restricted to the directory",
+		},
+		cli.BoolFlag{
+			Name:  "allow-exec",
+			Usage: "Allow sandboxed programs to run commands",
+		},
 	}
 	app.Commands = []cli.Command{
 		{
@@ -66,9 +265,59 @@ func main() {
 		},
 		{
 			Name:   "run",
-			Usage:  "Execute a program file",
+			Usage:  "Execute a program file, arguments following it are passed to the program",
 			Action: executeProgramFile,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "profile",
+					Usage: "Write a pprof profile of the execution to the file and report the top entries",
+				},
+				cli.IntFlag{
+					Name:  "top",
+					Value: 10,
+					Usage: "Number of functions and lines shown in the profile report",
+				},
+				cli.BoolFlag{
+					Name:  "trace",
+					Usage: "Log each evaluated node with its result",
+				},
+			},
 		},
+		{
+			Name:   "debug",
+			Usage:  "Execute a program file in the step debugger",
+			Action: debugProgramFile,
+			Flags: []cli.Flag{
+				cli.IntSliceFlag{
+					Name:  "break",
+					Usage: "Set a breakpoint on the line instead of pausing at the first statement",
+				},
+			},
+		},
+		{
+			Name:   "dap",
+			Usage:  "Run a debug adapter communicating over stdio",
+			Action: runDebugAdapter,
+		},
+		{
+			Name:   "lint",
+			Usage:  "Report suspicious constructs in program files",
+			Action: lintProgramFiles,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "config",
+					Usage: "Load rule configuration from JSON file",
+				},
+			},
+		},
+		{
+			Name:   "lsp",
+			Usage:  "Run a language server communicating over stdio",
+			Action: runLanguageServer,
+		},
+	}
+	if err := app.Run(os.Args); err != nil {
+		printError(err)
+		os.Exit(1)
 	}
-	app.Run(os.Args)
 }
@@ -0,0 +1,455 @@
+// Package dap provides a Debug Adapter Protocol server for Tealang programs.
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/debugger"
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/repl"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+)
+
+// resumption tells the paused program how to continue.
+type resumption struct {
+	mode debugger.Mode
+	err  error
+}
+
+// Server answers Debug Adapter Protocol requests, running the launched program in the background.
+type Server struct {
+	reader *bufio.Reader
+	writer io.Writer
+	output sync.Mutex
+	seq    int
+
+	program    string
+	code       string
+	statements map[int]bool
+	entry      bool
+	noDebug    bool
+	debugger   *debugger.Debugger
+
+	// System is the system the launched program runs with.
+	// By default the program reads no input, its output is sent as output events and it has no access to files.
+	System runtime.System
+
+	mutex      sync.Mutex
+	state      *debugger.State
+	frames     []debugger.Frame
+	references map[int]*runtime.Namespace
+	resume     chan resumption
+}
+
+// read fetches the next message content, framed by a Content-Length header.
+func (s *Server) read() ([]byte, error) {
+	length := -1
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			length, err = strconv.Atoi(strings.TrimSpace(line[len("content-length:"):]))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid content length")
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("missing content length")
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, content); err != nil {
+		return nil, errors.Wrap(err, "failed reading content")
+	}
+	return content, nil
+}
+
+// write sends the message, numbering it using the next sequence number.
+func (s *Server) write(msg interface{}) error {
+	s.output.Lock()
+	defer s.output.Unlock()
+	s.seq++
+	switch msg := msg.(type) {
+	case *response:
+		msg.Seq = s.seq
+	case *event:
+		msg.Seq = s.seq
+	}
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed encoding message")
+	}
+	_, err = fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	return err
+}
+
+func (s *Server) notify(name string, body interface{}) error {
+	return s.write(&event{Type: "event", Event: name, Body: body})
+}
+
+// outputWriter sends everything written to it as output events of its category.
+type outputWriter struct {
+	server   *Server
+	category string
+}
+
+func (w outputWriter) Write(p []byte) (int, error) {
+	if err := w.server.notify("output", outputEvent{Category: w.category, Output: string(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Output returns a writer sending everything written to it as output events of the given category,
+// e.g. the output of the program.
+func (s *Server) Output(category string) io.Writer {
+	return outputWriter{server: s, category: category}
+}
+
+// pause is the debugger controller, it blocks until the client resumes the execution.
+func (s *Server) pause(state debugger.State) (debugger.Mode, error) {
+	s.mutex.Lock()
+	s.state = &state
+	s.frames = debugger.Frames(state)
+	s.references = make(map[int]*runtime.Namespace)
+	s.mutex.Unlock()
+	s.notify("stopped", stoppedEvent{Reason: state.Reason.String(), ThreadID: threadID, AllThreadsStopped: true})
+	r := <-s.resume
+	return r.mode, r.err
+}
+
+// paused returns the state of the paused program, it fails if the program is running.
+func (s *Server) paused() (*debugger.State, error) {
+	if s.state == nil {
+		return nil, errors.New("program is not paused")
+	}
+	return s.state, nil
+}
+
+// continueWith resumes the paused program.
+func (s *Server) continueWith(r resumption) {
+	s.mutex.Lock()
+	paused := s.state != nil
+	s.state, s.frames, s.references = nil, nil, nil
+	s.mutex.Unlock()
+	if paused {
+		s.resume <- r
+	}
+}
+
+// run executes the launched program and reports its termination.
+func (s *Server) run() {
+	cfg := repl.Config{System: s.System}
+	if !s.noDebug {
+		cfg.Hook = s.debugger
+	}
+	exitCode := 0
+	if _, err := repl.New(cfg).Interpret(s.code); err != nil && errors.Cause(err) != debugger.ErrAborted {
+		s.notify("output", outputEvent{Category: "stderr", Output: runtime.Report(err)})
+		exitCode = 1
+	}
+	s.notify("exited", exitedEvent{ExitCode: exitCode})
+	s.notify("terminated", nil)
+}
+
+// statementLines collects the lines statements of the program start at.
+func statementLines(ast nodes.Node) map[int]bool {
+	lines := make(map[int]bool)
+	nodes.Inspect(ast, func(n nodes.Node) bool {
+		if seq, ok := n.(*nodes.Sequence); ok {
+			for _, stmt := range seq.Statements() {
+				if line, _ := nodes.Position(stmt); line > 0 {
+					lines[line] = true
+				}
+			}
+		}
+		return n != nil
+	})
+	return lines
+}
+
+func (s *Server) launch(args launchArguments) error {
+	code, err := ioutil.ReadFile(args.Program)
+	if err != nil {
+		return errors.Wrap(err, "can not launch program")
+	}
+	ast, _, err := parser.Parse(lexer.Lex(string(code)))
+	if err != nil {
+		return errors.Wrap(err, "can not launch program")
+	}
+	s.program, s.code = args.Program, string(code)
+	s.statements = statementLines(ast)
+	s.entry, s.noDebug = args.StopOnEntry, args.NoDebug
+	return nil
+}
+
+// setBreakpoints replaces all breakpoints, lines without statements are reported as unverified.
+func (s *Server) setBreakpoints(args setBreakpointsArguments) interface{} {
+	for _, line := range s.debugger.Breakpoints() {
+		s.debugger.Clear(line)
+	}
+	result := make([]breakpoint, len(args.Breakpoints))
+	for i, bp := range args.Breakpoints {
+		result[i] = breakpoint{Line: bp.Line, Verified: true}
+		if s.statements != nil && !s.statements[bp.Line] {
+			result[i].Verified, result[i].Message = false, "no statement on this line"
+			continue
+		}
+		s.debugger.Break(bp.Line)
+	}
+	return map[string]interface{}{"breakpoints": result}
+}
+
+func (s *Server) stackTrace(args stackTraceArguments) (interface{}, error) {
+	if _, err := s.paused(); err != nil {
+		return nil, err
+	}
+	frames := make([]stackFrame, 0, len(s.frames))
+	for i, frame := range s.frames {
+		if i < args.StartFrame || args.Levels > 0 && len(frames) >= args.Levels {
+			continue
+		}
+		name := frame.Function
+		if frame.Signature != nil {
+			name += frame.Signature.String()
+		}
+		frames = append(frames, stackFrame{
+			ID:     i + 1,
+			Name:   name,
+			Source: source{Name: filepath.Base(s.program), Path: s.program},
+			Line:   frame.Line,
+			Column: frame.Column,
+		})
+	}
+	return map[string]interface{}{"stackFrames": frames, "totalFrames": len(s.frames)}, nil
+}
+
+// frame looks up the namespace of the frame with the given ID.
+func (s *Server) frame(id int) (*runtime.Namespace, error) {
+	if id < 1 || id > len(s.frames) {
+		return nil, errors.Errorf("unknown frame %d", id)
+	}
+	return s.frames[id-1].Namespace, nil
+}
+
+func (s *Server) scopes(args scopesArguments) (interface{}, error) {
+	state, err := s.paused()
+	if err != nil {
+		return nil, err
+	}
+	ns, err := s.frame(args.FrameID)
+	if err != nil {
+		return nil, err
+	}
+	result := []scope{}
+	for i, sc := range debugger.Scopes(state.Context, ns) {
+		ref := len(s.references) + 1
+		s.references[ref] = sc.Namespace
+		name := "Local"
+		if sc.Global {
+			name = "Global"
+		} else if i > 0 {
+			name = fmt.Sprintf("Level %d", i)
+		}
+		result = append(result, scope{Name: name, VariablesReference: ref})
+	}
+	return map[string]interface{}{"scopes": result}, nil
+}
+
+func (s *Server) variables(args variablesArguments) (interface{}, error) {
+	state, err := s.paused()
+	if err != nil {
+		return nil, err
+	}
+	ns, ok := s.references[args.VariablesReference]
+	if !ok {
+		return nil, errors.Errorf("unknown variables reference %d", args.VariablesReference)
+	}
+	result := []variable{}
+	for _, value := range debugger.Scopes(state.Context, ns)[0].Values {
+		result = append(result, variable{Name: value.Name, Value: value.String(), Type: value.Typeflag.String()})
+	}
+	return map[string]interface{}{"variables": result}, nil
+}
+
+func (s *Server) evaluate(args evaluateArguments) (interface{}, error) {
+	state, err := s.paused()
+	if err != nil {
+		return nil, err
+	}
+	ns := state.Context.Namespace
+	if args.FrameID != nil {
+		if ns, err = s.frame(*args.FrameID); err != nil {
+			return nil, err
+		}
+	}
+	value, err := debugger.Evaluate(state.Context, ns, args.Expression)
+	if err != nil {
+		return nil, err
+	}
+	result := evaluateResult{Result: value.String()}
+	if value.Type != nil {
+		result.Type = value.Typeflag.String()
+	}
+	return result, nil
+}
+
+// handle dispatches the request, the returned values are the response body and error.
+// The returned flag reports whether the server should stop after responding.
+func (s *Server) handle(req request) (interface{}, bool, error) {
+	decode := func(v interface{}) error {
+		if len(req.Arguments) == 0 {
+			return nil
+		}
+		return errors.Wrap(json.Unmarshal(req.Arguments, v), "invalid arguments")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch req.Command {
+	case "initialize":
+		return capabilities{
+			SupportsConfigurationDoneRequest: true,
+			SupportsEvaluateForHovers:        true,
+			SupportsTerminateRequest:         true,
+		}, false, nil
+	case "launch":
+		var args launchArguments
+		if err := decode(&args); err != nil {
+			return nil, false, err
+		}
+		return nil, false, s.launch(args)
+	case "setBreakpoints":
+		var args setBreakpointsArguments
+		if err := decode(&args); err != nil {
+			return nil, false, err
+		}
+		return s.setBreakpoints(args), false, nil
+	case "setExceptionBreakpoints":
+		return nil, false, nil
+	case "configurationDone":
+		if s.code == "" {
+			return nil, false, errors.New("no program launched")
+		}
+		// breakpoints may have been set before, move them to a debugger pausing on entry if requested
+		dbg := debugger.New(s.pause, s.entry)
+		for _, line := range s.debugger.Breakpoints() {
+			dbg.Break(line)
+		}
+		s.debugger = dbg
+		go s.run()
+		return nil, false, nil
+	case "threads":
+		return map[string]interface{}{"threads": []thread{{ID: threadID, Name: "main"}}}, false, nil
+	case "stackTrace":
+		var args stackTraceArguments
+		if err := decode(&args); err != nil {
+			return nil, false, err
+		}
+		body, err := s.stackTrace(args)
+		return body, false, err
+	case "scopes":
+		var args scopesArguments
+		if err := decode(&args); err != nil {
+			return nil, false, err
+		}
+		body, err := s.scopes(args)
+		return body, false, err
+	case "variables":
+		var args variablesArguments
+		if err := decode(&args); err != nil {
+			return nil, false, err
+		}
+		body, err := s.variables(args)
+		return body, false, err
+	case "evaluate":
+		var args evaluateArguments
+		if err := decode(&args); err != nil {
+			return nil, false, err
+		}
+		body, err := s.evaluate(args)
+		return body, false, err
+	case "continue", "next", "stepIn", "stepOut":
+		if _, err := s.paused(); err != nil {
+			return nil, false, err
+		}
+		return map[string]interface{}{"allThreadsContinued": true}, false, nil
+	case "disconnect", "terminate":
+		return nil, true, nil
+	}
+	return nil, false, errors.Errorf("unsupported command %s", req.Command)
+}
+
+// resumptions maps the commands resuming the program to the debugger modes.
+var resumptions = map[string]debugger.Mode{
+	"continue": debugger.ModeContinue,
+	"next":     debugger.ModeStepOver,
+	"stepIn":   debugger.ModeStepInto,
+	"stepOut":  debugger.ModeStepOut,
+}
+
+// Serve processes incoming requests until the client disconnects.
+// A paused program is aborted when the client disconnects.
+func (s *Server) Serve() error {
+	for {
+		content, err := s.read()
+		if err != nil {
+			s.continueWith(resumption{err: debugger.ErrAborted})
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed reading message")
+		}
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			return errors.Wrap(err, "invalid request")
+		}
+		body, stop, err := s.handle(req)
+		resp := &response{Type: "response", RequestSeq: req.Seq, Command: req.Command, Success: err == nil, Body: body}
+		if err != nil {
+			resp.Message = err.Error()
+		}
+		if err := s.write(resp); err != nil {
+			return errors.Wrap(err, "failed writing response")
+		}
+		switch {
+		case req.Command == "initialize":
+			s.notify("initialized", nil)
+		case stop:
+			s.continueWith(resumption{err: debugger.ErrAborted})
+			return nil
+		case err == nil:
+			if mode, ok := resumptions[req.Command]; ok {
+				s.continueWith(resumption{mode: mode})
+			}
+		}
+	}
+}
+
+// NewServer constructs a new server communicating using the given streams.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	s := &Server{
+		reader: bufio.NewReader(in),
+		writer: out,
+		resume: make(chan resumption),
+	}
+	s.debugger = debugger.New(s.pause, false)
+	s.System = &runtime.Sandbox{Out: s.Output("stdout"), Err: s.Output("stderr")}
+	return s
+}
@@ -0,0 +1,119 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProgram = `func double(x: int): int {
+    let y = x * 2;
+    return y;
+}
+var a = 1;
+a = double(a);
+print(a);
+`
+
+// client drives the server like an editor would.
+type client struct {
+	t      *testing.T
+	seq    int
+	in     io.Writer
+	server *Server
+}
+
+func (c *client) send(command string, args string) {
+	c.seq++
+	msg := fmt.Sprintf(`{"seq":%d,"type":"request","command":"%s","arguments":%s}`, c.seq, command, args)
+	fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+}
+
+// expect reads messages until the response to the last request or the event with the given name is received.
+func (c *client) expect(name string, contains ...string) {
+	for {
+		content, err := c.server.read()
+		if err != nil {
+			c.t.Fatalf("expected %s, got error %v", name, err)
+		}
+		var msg struct {
+			Type       string `json:"type"`
+			Event      string `json:"event"`
+			Command    string `json:"command"`
+			RequestSeq int    `json:"request_seq"`
+		}
+		json.Unmarshal(content, &msg)
+		if msg.Event != name && (msg.Type != "response" || msg.Command != name || msg.RequestSeq != c.seq) {
+			continue
+		}
+		for _, want := range contains {
+			if !strings.Contains(string(content), want) {
+				c.t.Errorf("%s = %s, want to contain %s", name, content, want)
+			}
+		}
+		return
+	}
+}
+
+func TestServer_Serve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	program := filepath.Join(dir, "main.tea")
+	if err := ioutil.WriteFile(program, []byte(testProgram), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inReader, inWriter := io.Pipe()
+	outReader, outWriter := io.Pipe()
+	server := NewServer(inReader, outWriter)
+	done := make(chan error)
+	go func() { done <- server.Serve() }()
+	c := &client{t: t, in: inWriter, server: &Server{reader: bufio.NewReader(outReader)}}
+
+	c.send("initialize", `{"adapterID":"tea"}`)
+	c.expect("initialize", `"supportsConfigurationDoneRequest":true`)
+	c.expect("initialized")
+	c.send("launch", fmt.Sprintf(`{"program":%q}`, program))
+	c.expect("launch", `"success":true`)
+	c.send("setBreakpoints", fmt.Sprintf(`{"source":{"path":%q},"breakpoints":[{"line":3},{"line":4}]}`, program))
+	c.expect("setBreakpoints", `{"verified":true,"line":3}`, `{"verified":false,"line":4`)
+	c.send("configurationDone", `{}`)
+	c.expect("configurationDone", `"success":true`)
+	c.expect("stopped", `"reason":"breakpoint"`)
+
+	c.send("stackTrace", `{"threadId":1}`)
+	c.expect("stackTrace", `"name":"double(x: int) -\u003e int","source":{"name":"main.tea"`, `"line":3,"column":5`, `"name":"main"`, `"line":6,"column":5`, `"totalFrames":2`)
+	c.send("scopes", `{"frameId":1}`)
+	c.expect("scopes", `{"name":"Local","variablesReference":1`, `{"name":"Global","variablesReference":2`)
+	c.send("variables", `{"variablesReference":1}`)
+	c.expect("variables", `{"name":"x","value":"1","type":"int"`, `{"name":"y","value":"2","type":"int"`)
+	c.send("evaluate", `{"expression":"y * 10","frameId":1}`)
+	c.expect("evaluate", `"result":"20","type":"int"`)
+	c.send("evaluate", `{"expression":"a","frameId":2}`)
+	c.expect("evaluate", `"result":"1"`)
+	c.send("evaluate", `{"expression":"y","frameId":2}`)
+	c.expect("evaluate", `"success":false`)
+
+	c.send("stepOut", `{"threadId":1}`)
+	c.expect("stepOut", `"success":true`)
+	c.expect("stopped", `"reason":"step"`)
+	c.send("continue", `{"threadId":1}`)
+	c.expect("continue", `"success":true`)
+	c.expect("output", `"category":"stdout"`, `"output":"2`)
+	c.expect("exited", `"exitCode":0`)
+	c.expect("terminated")
+	c.send("disconnect", `{}`)
+	c.expect("disconnect", `"success":true`)
+	if err := <-done; err != nil {
+		t.Errorf("Serve() unexpected error %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+// Package debugger provides a step debugger pausing the execution of Tealang programs at breakpoints.
+package debugger
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// Mode controls when the debugger pauses the execution next.
+type Mode int
+
+const (
+	// ModeContinue pauses at the next breakpoint.
+	ModeContinue Mode = iota
+	// ModeStepInto pauses at the next statement, entering function calls.
+	ModeStepInto
+	// ModeStepOver pauses at the next statement of the current function.
+	ModeStepOver
+	// ModeStepOut pauses at the next statement after the current function returned.
+	ModeStepOut
+)
+
+// Reason describes why the execution has been paused.
+type Reason int
+
+const (
+	// ReasonEntry is used when pausing at the first statement of the program.
+	ReasonEntry Reason = iota
+	// ReasonBreakpoint is used when a statement on a breakpoint line is reached.
+	ReasonBreakpoint
+	// ReasonStep is used when a step has been completed.
+	ReasonStep
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonEntry:
+		return "entry"
+	case ReasonBreakpoint:
+		return "breakpoint"
+	default:
+		return "step"
+	}
+}
+
+// State describes the paused execution.
+type State struct {
+	Context      *runtime.Context
+	Statement    nodes.Node
+	Line, Column int
+	Reason       Reason
+}
+
+// Controller is called when the execution is paused and decides how to resume it.
+// Returning an error aborts the execution.
+type Controller func(s State) (Mode, error)
+
+// ErrAborted is returned by controllers to stop the execution.
+var ErrAborted = errors.New("aborted by debugger")
+
+// Debugger is a runtime hook pausing the execution at breakpoints and after steps.
+// Breakpoints may be changed while the program is running.
+type Debugger struct {
+	mutex       sync.Mutex
+	breakpoints map[int]bool
+	controller  Controller
+	mode        Mode
+	depth       int
+	entry       bool
+}
+
+// Break sets a breakpoint on the given line.
+func (d *Debugger) Break(line int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.breakpoints[line] = true
+}
+
+// Clear removes the breakpoint from the given line.
+func (d *Debugger) Clear(line int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.breakpoints, line)
+}
+
+// Breakpoints returns the lines with breakpoints in ascending order.
+func (d *Debugger) Breakpoints() []int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	lines := make([]int, 0, len(d.breakpoints))
+	for line := range d.breakpoints {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// Step pauses the execution before the statement if a breakpoint or the current step requires it.
+func (d *Debugger) Step(c *runtime.Context, statement runtime.Evaluable) error {
+	n, ok := statement.(nodes.Node)
+	if !ok {
+		return nil
+	}
+	line, column := nodes.Position(n)
+	if line == 0 {
+		return nil
+	}
+	d.mutex.Lock()
+	breakpoint := d.breakpoints[line]
+	d.mutex.Unlock()
+	var reason Reason
+	switch {
+	case d.entry:
+		reason, d.entry = ReasonEntry, false
+	case breakpoint:
+		reason = ReasonBreakpoint
+	case d.mode == ModeStepInto,
+		d.mode == ModeStepOver && len(c.Stack) <= d.depth,
+		d.mode == ModeStepOut && len(c.Stack) < d.depth:
+		reason = ReasonStep
+	default:
+		return nil
+	}
+	mode, err := d.controller(State{
+		Context:   c,
+		Statement: n,
+		Line:      line,
+		Column:    column,
+		Reason:    reason,
+	})
+	if err != nil {
+		return err
+	}
+	d.mode, d.depth = mode, len(c.Stack)
+	return nil
+}
+
+// Frame is a function invocation visible from the paused statement.
+// The position is the one of the statement currently executed in the frame.
+type Frame struct {
+	Function     string
+	Signature    *runtime.Signature
+	Namespace    *runtime.Namespace
+	Line, Column int
+}
+
+// Frames lists the call stack of the paused execution from the innermost frame to the program itself.
+func Frames(s State) []Frame {
+	stack := s.Context.Stack
+	frames := make([]Frame, 0, len(stack)+1)
+	namespace, line, column := s.Context.Namespace, s.Line, s.Column
+	for i := len(stack) - 1; i >= 0; i-- {
+		frames = append(frames, Frame{
+			Function:  stack[i].Function,
+			Signature: &stack[i].Signature,
+			Namespace: namespace,
+			Line:      line,
+			Column:    column,
+		})
+		namespace, line, column = stack[i].Caller, stack[i].Line, stack[i].Column
+	}
+	return append(frames, Frame{
+		Function:  "main",
+		Namespace: namespace,
+		Line:      line,
+		Column:    column,
+	})
+}
+
+// Scope is a namespace level visible from the paused statement.
+type Scope struct {
+	Namespace *runtime.Namespace
+	Global    bool
+	Values    []runtime.Value
+}
+
+// Scopes lists the namespace chain from the given namespace to the global namespace.
+// Builtin functions are left out of the global scope.
+func Scopes(c *runtime.Context, ns *runtime.Namespace) []Scope {
+	scopes := []Scope{}
+	for ; ns != nil; ns = ns.Parent {
+		scope := Scope{Namespace: ns, Global: ns == c.GlobalNamespace, Values: []runtime.Value{}}
+		for _, item := range ns.Storage[runtime.SearchIdentifier] {
+			value, ok := item.(runtime.Value)
+			if !ok {
+				continue
+			}
+			if function, ok := value.Data.(runtime.Function); ok && value.Type == types.Function && function.Source == nil {
+				continue
+			}
+			scope.Values = append(scope.Values, value)
+		}
+		sort.Slice(scope.Values, func(i, j int) bool {
+			return scope.Values[i].Name < scope.Values[j].Name
+		})
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// Evaluate runs the expression in the given namespace of the paused context without triggering the debugger.
+// The namespace and behavior of the context are restored afterwards.
+func Evaluate(c *runtime.Context, ns *runtime.Namespace, expression string) (runtime.Value, error) {
+	ast, _, err := parser.Parse(lexer.Lex(expression))
+	if err != nil {
+		return runtime.Value{}, errors.Wrap(err, "failed to evaluate")
+	}
+	hook, behavior, backup := c.Hook, c.Behavior, c.Namespace
+	c.Hook, c.Namespace = nil, ns
+	defer func() { c.Hook, c.Behavior, c.Namespace = hook, behavior, backup }()
+	value, err := ast.Eval(c)
+	if err != nil {
+		return runtime.Value{}, errors.Wrap(err, "failed to evaluate")
+	}
+	return value, nil
+}
+
+// New constructs a new debugger calling the controller whenever the execution is paused.
+// If entry is set, the execution pauses at the first statement.
+func New(controller Controller, entry bool) *Debugger {
+	return &Debugger{
+		breakpoints: make(map[int]bool),
+		controller:  controller,
+		mode:        ModeContinue,
+		entry:       entry,
+	}
+}
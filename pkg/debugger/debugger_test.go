@@ -0,0 +1,98 @@
+package debugger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/functions"
+	"github.com/tealang/core/pkg/runtime/operators"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+const testProgram = `func double(x: int): int {
+    let y = x * 2;
+    return y;
+}
+var a = 1;
+a = double(a);
+a = double(a);
+`
+
+func TestDebugger_Step(t *testing.T) {
+	tests := []struct {
+		name        string
+		entry       bool
+		breakpoints []int
+		modes       []Mode
+		want        []int
+	}{
+		{"Continue", true, nil, nil, []int{1}},
+		{"Breakpoint", false, []int{2}, nil, []int{2, 2}},
+		{"StepInto", true, nil, []Mode{ModeStepInto, ModeStepInto, ModeStepInto, ModeStepInto, ModeStepInto}, []int{1, 5, 6, 2, 3, 7}},
+		{"StepOver", true, nil, []Mode{ModeStepOver, ModeStepOver, ModeStepOver}, []int{1, 5, 6, 7}},
+		{"StepOut", false, []int{2}, []Mode{ModeStepOut}, []int{2, 7, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []int{}
+			dbg := New(func(s State) (Mode, error) {
+				got = append(got, s.Line)
+				if len(tt.modes) < len(got) {
+					return ModeContinue, nil
+				}
+				return tt.modes[len(got)-1], nil
+			}, tt.entry)
+			for _, line := range tt.breakpoints {
+				dbg.Break(line)
+			}
+			ctx := runtime.NewContext()
+			operators.Load(ctx)
+			types.Load(ctx)
+			functions.Load(ctx)
+			ctx.Hook = dbg
+			ast, _, err := parser.Parse(lexer.Lex(testProgram))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error %v", err)
+			}
+			if _, err := ast.Eval(ctx); err != nil {
+				t.Fatalf("Eval() unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paused at lines %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopes(t *testing.T) {
+	var scopes []Scope
+	dbg := New(func(s State) (Mode, error) {
+		scopes = Scopes(s.Context, s.Context.Namespace)
+		if v, err := Evaluate(s.Context, s.Context.Namespace, "x + 1"); err != nil || v.Data != int64(3) {
+			t.Errorf("Evaluate() = %v, %v, want 3", v, err)
+		}
+		return ModeContinue, nil
+	}, false)
+	dbg.Break(2)
+	ctx := runtime.NewContext()
+	operators.Load(ctx)
+	types.Load(ctx)
+	functions.Load(ctx)
+	ctx.Hook = dbg
+	ast, _, _ := parser.Parse(lexer.Lex("func f(x: int) {\n    return x;\n}\nf(2);"))
+	if _, err := ast.Eval(ctx); err != nil {
+		t.Fatalf("Eval() unexpected error %v", err)
+	}
+	if len(scopes) != 2 {
+		t.Fatalf("Scopes() returned %d scopes, want 2", len(scopes))
+	}
+	if len(scopes[0].Values) != 1 || scopes[0].Values[0].Name != "x" {
+		t.Errorf("innermost scope = %v, want x", scopes[0].Values)
+	}
+	if !scopes[1].Global || len(scopes[1].Values) != 1 || scopes[1].Values[0].Name != "f" {
+		t.Errorf("global scope = %v, want f", scopes[1].Values)
+	}
+}
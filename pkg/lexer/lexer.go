@@ -6,16 +6,30 @@ import (
 )
 
 // Lex converts the input into a series of tokens.
+// Comments are kept as tokens, so that tools can access them.
 func Lex(input string) []tokens.Token {
+	var (
+		line, lineStart, scanned = 1, 0, 0
+		output                   []tokens.Token
+	)
+	// locate returns line and column of the character at the given offset
+	locate := func(offset int) (int, int) {
+		for ; scanned < offset; scanned++ {
+			if input[scanned] == '\n' {
+				line++
+				lineStart = scanned + 1
+			}
+		}
+		return line, offset - lineStart + 1
+	}
 	active := tokens.Token{
 		Value: "",
 		Type:  nil,
 	}
-	var output []tokens.Token
 	for i := 0; i < len(input); i++ {
 		c := input[i]
 		value := active.Value + string(c)
-		if active.Type != nil && active.Type.Match(value) {
+		if active.Type != nil && active.Type != tokens.SingleLineComment && active.Type.Match(value) {
 			active.Value = value
 		} else {
 			if active.Type != nil {
@@ -25,12 +39,14 @@ func Lex(input string) []tokens.Token {
 				Value: string(c),
 				Type:  tokens.FindMatch(string(c)),
 			}
+			active.Line, active.Column = locate(i)
 			switch active.Type {
 			case tokens.SingleLineComment:
-				for i < len(input) && input[i] != '\n' {
+				start := i
+				for i+1 < len(input) && input[i+1] != '\n' {
 					i++
 				}
-				active = tokens.Token{Type: tokens.Whitespace}
+				active.Value = input[start : i+1]
 			}
 		}
 	}
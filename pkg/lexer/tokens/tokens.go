@@ -31,9 +31,11 @@ func (tt Type) String() string {
 }
 
 // Token is a string value with an associated type.
+// Line and column refer to the first character of the token in the source code, starting at 1.
 type Token struct {
-	Type  *Type
-	Value string
+	Type         *Type
+	Value        string
+	Line, Column int
 }
 
 func (t Token) String() string {
@@ -52,11 +54,11 @@ var (
 	}
 	Operator = &Type{
 		Name:  "operator",
-		Match: NewTokenMatcher(`^([+\-*/=:<>!%^&|.]|([+\-*/^%<>=!][=?]{1})|([|^]\|)|(&&))$`),
+		Match: NewTokenMatcher(`^([+\-*/=:<>!%^&|.~?]|(\?\?)|([+\-*/^%<>=!&|][=?]{1})|([|^]\|)|(&&)|((<<|>>|//)=?))$`),
 	}
 	AssignmentOperator = &Type{
 		Name: "assignmentOperator",
-		Match: NewTokenMatcher(`^([+\-*/^%<>!]?[=]{1})$`),
+		Match: NewTokenMatcher(`^(([+\-*/^%<>!&|]|<<|>>|//)?[=]{1})$`),
 	}
 	Whitespace = &Type{
 		Name:  "whitespace",
@@ -64,7 +66,7 @@ var (
 	}
 	Number = &Type{
 		Name:  "number",
-		Match: NewTokenMatcher(`^\-?[0-9]+(\.[0-9]*)?$`),
+		Match: NewTokenMatcher(`^\-?[0-9]+(\.[0-9]*)?[nd]?$`),
 	}
 	Identifier = &Type{
 		Name:  "identifier",
@@ -0,0 +1,69 @@
+package linter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// Rules supported by the linter.
+const (
+	UnusedVariable      = "unused-variable"
+	ShadowedDeclaration = "shadowed-declaration"
+	PreferConstant      = "prefer-let"
+	UnreachableCode     = "unreachable-code"
+	MissingDefault      = "missing-default"
+	ConstantAssignment  = "constant-assignment"
+)
+
+// Rules lists all available rules.
+var Rules = []string{
+	UnusedVariable,
+	ShadowedDeclaration,
+	PreferConstant,
+	UnreachableCode,
+	MissingDefault,
+	ConstantAssignment,
+}
+
+// Config enables or disables linter rules.
+type Config struct {
+	Rules map[string]bool `json:"rules"`
+}
+
+// Enabled checks if the rule is enabled.
+func (cfg Config) Enabled(rule string) bool {
+	enabled, ok := cfg.Rules[rule]
+	return !ok || enabled
+}
+
+// DefaultConfig returns a configuration with all rules enabled.
+func DefaultConfig() Config {
+	rules := make(map[string]bool)
+	for _, r := range Rules {
+		rules[r] = true
+	}
+	return Config{Rules: rules}
+}
+
+// LoadConfig reads a JSON configuration file, rules missing in the file stay enabled.
+// For example, {"rules": {"prefer-let": false}} disables the prefer-let rule.
+func LoadConfig(file string) (Config, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "can not read config")
+	}
+	var loaded Config
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return Config{}, errors.Wrap(err, "can not parse config")
+	}
+	cfg := DefaultConfig()
+	for rule, enabled := range loaded.Rules {
+		if _, ok := cfg.Rules[rule]; !ok {
+			return Config{}, errors.Errorf("unknown rule %s", rule)
+		}
+		cfg.Rules[rule] = enabled
+	}
+	return cfg, nil
+}
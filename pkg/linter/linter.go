@@ -0,0 +1,312 @@
+// Package linter provides static analysis of Tealang programs reporting suspicious constructs.
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/lexer/tokens"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+)
+
+// suppressionPrefix starts a comment disabling rules for the line of the comment and the following line.
+// It may be followed by a comma-separated list of rules, otherwise all rules are disabled.
+const suppressionPrefix = "lint:ignore"
+
+// Diagnostic is a problem reported by the linter.
+type Diagnostic struct {
+	Rule         string
+	Line, Column int
+	Message      string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", d.Line, d.Column, d.Message, d.Rule)
+}
+
+// Reference links the usage of an alias to the node declaring it.
+type Reference struct {
+	Alias       string
+	Usage       nodes.Node
+	Declaration nodes.Node
+}
+
+type symbol struct {
+	alias      string
+	node       nodes.Node
+	constant   bool
+	function   bool
+	parameter  bool
+	used       bool
+	reassigned bool
+}
+
+// scope mirrors a runtime namespace level.
+type scope struct {
+	parent    *scope
+	symbols   map[string]*symbol
+	declared  []*symbol
+	functions []*nodes.FunctionLiteral
+}
+
+func (s *scope) find(alias string) *symbol {
+	if sym, ok := s.symbols[alias]; ok {
+		return sym
+	}
+	if s.parent != nil {
+		return s.parent.find(alias)
+	}
+	return nil
+}
+
+type linter struct {
+	scope       *scope
+	diagnostics []Diagnostic
+	references  []Reference
+}
+
+func (l *linter) report(rule string, n nodes.Node, format string, args ...interface{}) {
+	line, column := nodes.Position(n)
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		Rule:    rule,
+		Line:    line,
+		Column:  column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) open() {
+	l.scope = &scope{
+		parent:  l.scope,
+		symbols: make(map[string]*symbol),
+	}
+}
+
+// close analyzes the function bodies defined in the scope, since they may refer to later declarations,
+// and reports unused and never reassigned variables.
+func (l *linter) close() {
+	for _, literal := range l.scope.functions {
+		l.function(literal)
+	}
+	for _, sym := range l.scope.declared {
+		if sym.function || sym.parameter {
+			continue
+		}
+		if !sym.used {
+			l.report(UnusedVariable, sym.node, "%s is declared but never used", sym.alias)
+		}
+		if !sym.constant && !sym.reassigned {
+			l.report(PreferConstant, sym.node, "%s is never reassigned, declare it using let", sym.alias)
+		}
+	}
+	l.scope = l.scope.parent
+}
+
+func (l *linter) declare(n nodes.Node, sym *symbol) {
+	if !sym.parameter && l.scope.parent != nil && l.scope.parent.find(sym.alias) != nil {
+		l.report(ShadowedDeclaration, n, "%s shadows a declaration of an outer scope", sym.alias)
+	}
+	sym.node = n
+	l.scope.symbols[sym.alias] = sym
+	l.scope.declared = append(l.scope.declared, sym)
+}
+
+func (l *linter) use(n nodes.Node, alias string) {
+	if sym := l.scope.find(alias); sym != nil {
+		sym.used = true
+		l.references = append(l.references, Reference{Alias: alias, Usage: n, Declaration: sym.node})
+	}
+}
+
+func (l *linter) assign(n nodes.Node, alias string) {
+	sym := l.scope.find(alias)
+	if sym == nil {
+		return
+	}
+	l.references = append(l.references, Reference{Alias: alias, Usage: n, Declaration: sym.node})
+	if sym.constant {
+		l.report(ConstantAssignment, n, "can not assign to constant %s", alias)
+	}
+	sym.reassigned = true
+}
+
+// function analyzes the function body in a new scope containing the parameters.
+func (l *linter) function(literal *nodes.FunctionLiteral) {
+	l.open()
+	for _, arg := range literal.Args {
+		if param, ok := arg.Value().(*nodes.Literal); ok {
+			l.declare(arg, &symbol{alias: param.Value.Name, parameter: true})
+		}
+	}
+	l.visit(literal.Body())
+	l.close()
+}
+
+// statements visits a list of statements and reports statements following a control flow change.
+func (l *linter) statements(stmts []nodes.Node) {
+	reported := false
+	for i, n := range stmts {
+		l.visit(n)
+		ctrl, ok := n.(*nodes.Controller)
+		if !ok || reported || i == len(stmts)-1 {
+			continue
+		}
+		switch ctrl.Behavior {
+		case runtime.BehaviorReturn, runtime.BehaviorBreak, runtime.BehaviorContinue:
+			l.report(UnreachableCode, stmts[i+1], "unreachable code after %s", ctrl.Source())
+			reported = true
+		}
+	}
+}
+
+func (l *linter) visit(n nodes.Node) {
+	switch n := n.(type) {
+	case *nodes.Sequence:
+		if n.Substitute {
+			l.open()
+			defer l.close()
+		}
+		l.statements(n.Statements())
+	case *nodes.Declaration:
+		for _, v := range n.Values() {
+			l.visit(v)
+		}
+		for i, alias := range n.Alias {
+			sym := &symbol{alias: alias, constant: n.Constant}
+			if i < len(n.Childs) {
+				_, sym.function = n.Childs[i].(*nodes.FunctionLiteral)
+			}
+			l.declare(n, sym)
+		}
+	case *nodes.Assignment:
+		for _, v := range n.Values() {
+			l.visit(v)
+		}
+		for _, alias := range n.Alias {
+			l.assign(n, alias)
+		}
+	case *nodes.Identifier:
+		// members of modules are provided by the runtime and never declared by the program
+		if n.Module == "" {
+			l.use(n, n.Alias)
+		}
+	case *nodes.FunctionCall:
+		if n.Module == "" {
+			l.use(n, n.Alias)
+		}
+		for _, arg := range n.Arguments() {
+			l.visit(arg)
+		}
+	case *nodes.FunctionLiteral:
+		l.scope.functions = append(l.scope.functions, n)
+	case *nodes.OperatorDefinition:
+		l.scope.functions = append(l.scope.functions, &n.FunctionLiteral)
+	case *nodes.Conditional:
+		l.visit(n.Condition())
+		l.open()
+		l.visit(n.Body())
+		l.close()
+	case *nodes.Loop:
+		l.visit(n.Condition())
+		l.open()
+		l.visit(n.Body())
+		l.close()
+	case *nodes.Match:
+		if n.Default() == nil {
+			l.report(MissingDefault, n, "match statement without default case")
+		}
+		l.open()
+		for _, c := range n.Children() {
+			l.visit(c)
+		}
+		l.close()
+	default:
+		for _, c := range n.Children() {
+			l.visit(c)
+		}
+	}
+}
+
+// analyze runs the scope analysis on the syntax tree.
+func analyze(ast nodes.Node) *linter {
+	l := &linter{}
+	l.open()
+	l.visit(ast)
+	l.close()
+	return l
+}
+
+// Resolve links the usages of aliases in the syntax tree to their declarations, following the namespace levels
+// used at runtime. Aliases declared outside of the tree, e.g. builtin functions, are not included.
+func Resolve(ast nodes.Node) []Reference {
+	return analyze(ast).references
+}
+
+// suppressions collects the rules disabled by comments for each line.
+// An empty rule list disables all rules.
+func suppressions(input []tokens.Token) map[int][]string {
+	lines := make(map[int][]string)
+	for _, tk := range input {
+		if tk.Type != tokens.SingleLineComment {
+			continue
+		}
+		text := strings.TrimSpace(strings.TrimPrefix(tk.Value, "#"))
+		if !strings.HasPrefix(text, suppressionPrefix) {
+			continue
+		}
+		rules := []string{}
+		for _, r := range strings.Split(strings.TrimPrefix(text, suppressionPrefix), ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				rules = append(rules, r)
+			}
+		}
+		lines[tk.Line] = rules
+		lines[tk.Line+1] = rules
+	}
+	return lines
+}
+
+func suppressed(lines map[int][]string, d Diagnostic) bool {
+	rules, ok := lines[d.Line]
+	if !ok {
+		return false
+	}
+	if len(rules) == 0 {
+		return true
+	}
+	for _, r := range rules {
+		if r == d.Rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint parses the program and reports problems found by the enabled rules, ordered by their position.
+func Lint(input string, cfg Config) ([]Diagnostic, error) {
+	tks := lexer.Lex(input)
+	ast, _, err := parser.Parse(tks)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lint")
+	}
+	l := analyze(ast)
+	lines := suppressions(tks)
+	diagnostics := make([]Diagnostic, 0, len(l.diagnostics))
+	for _, d := range l.diagnostics {
+		if cfg.Enabled(d.Rule) && !suppressed(lines, d) {
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+	return diagnostics, nil
+}
@@ -0,0 +1,103 @@
+package linter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		cfg   Config
+		want  []string
+	}{
+		{
+			"No problems",
+			"let x = 1;\nprint(x);",
+			DefaultConfig(),
+			[]string{},
+		},
+		{
+			"Unused variable",
+			"let x = 1;",
+			DefaultConfig(),
+			[]string{"1:1: x is declared but never used (unused-variable)"},
+		},
+		{
+			"Never reassigned variable",
+			"var x = 1;\nprint(x);",
+			DefaultConfig(),
+			[]string{"1:1: x is never reassigned, declare it using let (prefer-let)"},
+		},
+		{
+			"Reassigned variable",
+			"var x = 1;\nx += 1;\nprint(x);",
+			DefaultConfig(),
+			[]string{},
+		},
+		{
+			"Shadowed declaration",
+			"let x = 1;\nif x > 0 {\n    let x = 2;\n    print(x);\n}",
+			DefaultConfig(),
+			[]string{"3:5: x shadows a declaration of an outer scope (shadowed-declaration)"},
+		},
+		{
+			"Unreachable code",
+			"func f(): int {\n    return 1;\n    print(2);\n}\nf();",
+			DefaultConfig(),
+			[]string{"3:5: unreachable code after return 1 (unreachable-code)"},
+		},
+		{
+			"Match without default",
+			"let x = 1;\nmatch x {\n    case 1 {}\n}",
+			DefaultConfig(),
+			[]string{"2:1: match statement without default case (missing-default)"},
+		},
+		{
+			"Assignment to constant",
+			"let x = 1;\nx = 2;\nprint(x);",
+			DefaultConfig(),
+			[]string{"2:1: can not assign to constant x (constant-assignment)"},
+		},
+		{
+			"Function using later declaration",
+			"func f(): int {\n    return x;\n}\nlet x = 1;\nf();",
+			DefaultConfig(),
+			[]string{},
+		},
+		{
+			"Disabled rule",
+			"var x = 1;\nprint(x);",
+			Config{Rules: map[string]bool{PreferConstant: false}},
+			[]string{},
+		},
+		{
+			"Suppressed rule",
+			"# lint:ignore prefer-let\nvar x = 1;\nprint(x);\nvar y = 2; # lint:ignore\nprint(y);",
+			DefaultConfig(),
+			[]string{},
+		},
+		{
+			"Suppressed other rule",
+			"var x = 1; # lint:ignore unused-variable\nprint(x);",
+			DefaultConfig(),
+			[]string{"1:1: x is never reassigned, declare it using let (prefer-let)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics, err := Lint(tt.input, tt.cfg)
+			if err != nil {
+				t.Fatalf("Lint() unexpected error %v", err)
+			}
+			got := make([]string, len(diagnostics))
+			for i, d := range diagnostics {
+				got[i] = d.String()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
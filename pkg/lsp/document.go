@@ -0,0 +1,287 @@
+package lsp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/lexer/tokens"
+	"github.com/tealang/core/pkg/linter"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// keywords are offered as completion items in addition to builtins and declarations.
+var keywords = []string{
+	"let", "var", "func", "operator", "return", "if", "else", "for", "break", "continue",
+	"match", "case", "default", "fallthrough", "true", "false", "null", "xor",
+}
+
+// document is an open text document and the results of its analysis.
+type document struct {
+	uri         string
+	text        string
+	ast         nodes.Node
+	diagnostics []diagnostic
+	references  []linter.Reference
+}
+
+// toRange converts a one-based source position of a token with the given length into a protocol range.
+func toRange(line, column, length int) textRange {
+	if line < 1 {
+		line, column = 1, 1
+	}
+	start := position{Line: line - 1, Character: column - 1}
+	return textRange{Start: start, End: position{Line: start.Line, Character: start.Character + length}}
+}
+
+// errorPosition looks up the token the parser stopped at.
+func errorPosition(input []tokens.Token, offset int) (int, int) {
+	cleaned := make([]tokens.Token, 0, len(input))
+	for _, tk := range input {
+		if tk.Type != tokens.Whitespace && tk.Type != tokens.SingleLineComment && tk.Type != nil {
+			cleaned = append(cleaned, tk)
+		}
+	}
+	if len(cleaned) == 0 {
+		return 1, 1
+	}
+	if offset >= len(cleaned) {
+		offset = len(cleaned) - 1
+	}
+	return cleaned[offset].Line, cleaned[offset].Column
+}
+
+// analyze parses the document text, collecting diagnostics and resolving references.
+func (doc *document) analyze() {
+	doc.ast, doc.references = nil, nil
+	doc.diagnostics = []diagnostic{}
+
+	input := lexer.Lex(doc.text)
+	ast, offset, err := parser.Parse(input)
+	if err != nil {
+		line, column := errorPosition(input, offset)
+		doc.diagnostics = append(doc.diagnostics, diagnostic{
+			Range:    toRange(line, column, 1),
+			Severity: severityError,
+			Source:   "tea",
+			Message:  err.Error(),
+		})
+		return
+	}
+	doc.ast = ast
+	doc.references = linter.Resolve(ast)
+
+	found, err := linter.Lint(doc.text, linter.DefaultConfig())
+	if err != nil {
+		return
+	}
+	for _, d := range found {
+		doc.diagnostics = append(doc.diagnostics, diagnostic{
+			Range:    toRange(d.Line, d.Column, 1),
+			Severity: severityWarning,
+			Code:     d.Rule,
+			Source:   "tea lint",
+			Message:  d.Message,
+		})
+	}
+}
+
+// aliasAt finds the identifier or function call at the given protocol position.
+func (doc *document) aliasAt(pos position) (nodes.Node, string) {
+	if doc.ast == nil {
+		return nil, ""
+	}
+	var (
+		found nodes.Node
+		alias string
+	)
+	nodes.Inspect(doc.ast, func(n nodes.Node) bool {
+		var name string
+		switch n := n.(type) {
+		case *nodes.Identifier:
+			name = n.Qualified()
+		case *nodes.FunctionCall:
+			name = n.Qualified()
+		default:
+			return n != nil
+		}
+		line, column := nodes.Position(n)
+		if line-1 == pos.Line && column-1 <= pos.Character && pos.Character < column-1+len(name) {
+			found, alias = n, name
+		}
+		return true
+	})
+	return found, alias
+}
+
+// declarationOf looks up the declaration of the alias used by the given node.
+func (doc *document) declarationOf(usage nodes.Node) nodes.Node {
+	for _, ref := range doc.references {
+		if ref.Usage == usage {
+			return ref.Declaration
+		}
+	}
+	return nil
+}
+
+// signature generates a readable signature of the function literal.
+func signature(literal *nodes.FunctionLiteral) string {
+	args := make([]string, len(literal.Args))
+	for i, arg := range literal.Args {
+		args[i] = arg.Source()
+	}
+	if literal.Returns != nil {
+		return fmt.Sprintf("func(%s): %s", strings.Join(args, ", "), literal.Returns.Tree)
+	}
+	return fmt.Sprintf("func(%s)", strings.Join(args, ", "))
+}
+
+// describe infers the typeflag of a value node without evaluating it.
+func describe(n nodes.Node) string {
+	switch n := n.(type) {
+	case *nodes.Literal:
+		if n.Value.Type == nil {
+			return "null"
+		}
+		return n.Value.Typeflag.String()
+	case *nodes.Type:
+		return n.Tree.String()
+	case *nodes.FunctionLiteral:
+		return signature(n)
+	}
+	return ""
+}
+
+// describeDeclaration generates the hover text for an alias declared in the document.
+func describeDeclaration(decl nodes.Node, alias string) string {
+	switch decl := decl.(type) {
+	case *nodes.Declaration:
+		for i, a := range decl.Alias {
+			if a != alias || i >= len(decl.Childs) {
+				continue
+			}
+			if typeflag := describe(decl.Childs[i]); typeflag != "" {
+				return fmt.Sprintf("%s: %s", alias, typeflag)
+			}
+		}
+	case *nodes.Type:
+		return fmt.Sprintf("%s: %s", alias, decl.Tree)
+	}
+	return alias
+}
+
+// describeBuiltin generates the hover text for a value provided by the runtime.
+func describeBuiltin(item runtime.SearchItem) string {
+	value, ok := item.(runtime.Value)
+	if !ok {
+		return item.Alias()
+	}
+	if function, ok := value.Data.(runtime.Function); ok && value.Type == types.Function {
+		return fmt.Sprintf("%s: %s %s", value.Name, value.Typeflag, function)
+	}
+	return value.VariableString()
+}
+
+// symbols lists the functions and operators defined in the document.
+func (doc *document) symbols() []symbolInformation {
+	result := []symbolInformation{}
+	if doc.ast == nil {
+		return result
+	}
+	nodes.Inspect(doc.ast, func(n nodes.Node) bool {
+		switch n := n.(type) {
+		case *nodes.Declaration:
+			for i, alias := range n.Alias {
+				if i >= len(n.Childs) {
+					break
+				}
+				if _, ok := n.Childs[i].(*nodes.FunctionLiteral); ok {
+					line, column := nodes.Position(n)
+					result = append(result, symbolInformation{
+						Name:     alias,
+						Kind:     symbolFunction,
+						Location: location{URI: doc.uri, Range: toRange(line, column, len(alias))},
+					})
+				}
+			}
+		case *nodes.OperatorDefinition:
+			line, column := nodes.Position(n)
+			result = append(result, symbolInformation{
+				Name:     n.Symbol,
+				Kind:     symbolOperator,
+				Location: location{URI: doc.uri, Range: toRange(line, column, len(n.Symbol))},
+			})
+		}
+		return n != nil
+	})
+	return result
+}
+
+// declared lists the aliases declared in the document.
+func (doc *document) declared() []completionItem {
+	items := []completionItem{}
+	if doc.ast == nil {
+		return items
+	}
+	seen := make(map[string]bool)
+	nodes.Inspect(doc.ast, func(n nodes.Node) bool {
+		decl, ok := n.(*nodes.Declaration)
+		if !ok {
+			return n != nil
+		}
+		for i, alias := range decl.Alias {
+			if seen[alias] {
+				continue
+			}
+			seen[alias] = true
+			item := completionItem{Label: alias, Kind: completionVariable}
+			if i < len(decl.Childs) {
+				item.Detail = describe(decl.Childs[i])
+				if _, ok := decl.Childs[i].(*nodes.FunctionLiteral); ok {
+					item.Kind = completionFunction
+				}
+			}
+			items = append(items, item)
+		}
+		return true
+	})
+	return items
+}
+
+// valueKind returns the completion kind of the value provided by the runtime.
+func valueKind(item runtime.SearchItem) int {
+	if value, ok := item.(runtime.Value); ok && value.Type == types.Function {
+		return completionFunction
+	}
+	return completionVariable
+}
+
+// builtins lists the items stored in the namespace including the members of modules, sorted by their alias.
+func builtins(ns *runtime.Namespace) []completionItem {
+	items := []completionItem{}
+	for _, space := range runtime.SearchSpaces {
+		for alias, item := range ns.Storage[space] {
+			switch space {
+			case runtime.SearchIdentifier:
+				items = append(items, completionItem{Label: alias, Kind: valueKind(item), Detail: describeBuiltin(item)})
+			case runtime.SearchModule:
+				items = append(items, completionItem{Label: alias, Kind: completionModule, Detail: "module"})
+				for member, item := range item.(*runtime.Module).Members.Storage[runtime.SearchIdentifier] {
+					items = append(items, completionItem{Label: alias + "." + member, Kind: valueKind(item), Detail: describeBuiltin(item)})
+				}
+			case runtime.SearchOperator:
+				items = append(items, completionItem{Label: alias, Kind: completionOperator, Detail: "operator"})
+			case runtime.SearchDatatype:
+				items = append(items, completionItem{Label: alias, Kind: completionClass, Detail: "type"})
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
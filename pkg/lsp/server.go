@@ -0,0 +1,289 @@
+// Package lsp provides a Language Server Protocol server for Tealang programs.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/functions"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/operators"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// Server answers Language Server Protocol requests received from an editor.
+type Server struct {
+	reader    *bufio.Reader
+	writer    io.Writer
+	documents map[string]*document
+	builtins  *runtime.Namespace
+	shutdown  bool
+}
+
+// read fetches the next message content, framed by a Content-Length header.
+func (s *Server) read() ([]byte, error) {
+	length := -1
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			length, err = strconv.Atoi(strings.TrimSpace(line[len("content-length:"):]))
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid content length")
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("missing content length")
+	}
+	content := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, content); err != nil {
+		return nil, errors.Wrap(err, "failed reading content")
+	}
+	return content, nil
+}
+
+func (s *Server) write(msg interface{}) error {
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed encoding message")
+	}
+	_, err = fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	return err
+}
+
+func (s *Server) respond(id *json.RawMessage, result interface{}, failure *responseError) error {
+	msg := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+	}
+	if failure != nil {
+		msg["error"] = failure
+	} else {
+		msg["result"] = result
+	}
+	return s.write(msg)
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return s.write(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	})
+}
+
+func (s *Server) publish(doc *document) error {
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         doc.uri,
+		Diagnostics: doc.diagnostics,
+	})
+}
+
+func (s *Server) open(uri, text string) error {
+	doc := &document{uri: uri, text: text}
+	doc.analyze()
+	s.documents[uri] = doc
+	return s.publish(doc)
+}
+
+// findBuiltin looks up the value provided by the runtime the identifier or function call refers to.
+func (s *Server) findBuiltin(usage nodes.Node) (runtime.SearchItem, error) {
+	var module, alias string
+	switch n := usage.(type) {
+	case *nodes.Identifier:
+		module, alias = n.Module, n.Alias
+	case *nodes.FunctionCall:
+		module, alias = n.Module, n.Alias
+	}
+	if module != "" {
+		return s.builtins.FindMember(runtime.SearchIdentifier, module, alias)
+	}
+	return s.builtins.Find(runtime.SearchIdentifier, alias)
+}
+
+func (s *Server) hover(params textDocumentPositionParams) interface{} {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	usage, alias := doc.aliasAt(params.Position)
+	if usage == nil {
+		return nil
+	}
+	var text string
+	if decl := doc.declarationOf(usage); decl != nil {
+		text = describeDeclaration(decl, alias)
+	} else if item, err := s.findBuiltin(usage); err == nil {
+		text = describeBuiltin(item)
+	} else {
+		return nil
+	}
+	line, column := nodes.Position(usage)
+	return hover{
+		Contents: markupContent{Kind: "plaintext", Value: text},
+		Range:    toRange(line, column, len(alias)),
+	}
+}
+
+func (s *Server) definition(params textDocumentPositionParams) interface{} {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	usage, alias := doc.aliasAt(params.Position)
+	if usage == nil {
+		return nil
+	}
+	decl := doc.declarationOf(usage)
+	if decl == nil {
+		return nil
+	}
+	line, column := nodes.Position(decl)
+	return location{URI: doc.uri, Range: toRange(line, column, len(alias))}
+}
+
+func (s *Server) completion(params textDocumentPositionParams) interface{} {
+	items := builtins(s.builtins)
+	if doc, ok := s.documents[params.TextDocument.URI]; ok {
+		items = append(items, doc.declared()...)
+	}
+	for _, kw := range keywords {
+		items = append(items, completionItem{Label: kw, Kind: completionKeyword})
+	}
+	return items
+}
+
+func (s *Server) symbols(params documentSymbolParams) interface{} {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return []symbolInformation{}
+	}
+	return doc.symbols()
+}
+
+// handle dispatches the request, the returned values are the response result and error.
+func (s *Server) handle(req request) (interface{}, *responseError) {
+	decode := func(v interface{}) *responseError {
+		if err := json.Unmarshal(req.Params, v); err != nil {
+			return &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	switch req.Method {
+	case "initialize":
+		result := initializeResult{}
+		result.Capabilities.TextDocumentSync = syncFull
+		result.Capabilities.HoverProvider = true
+		result.Capabilities.DefinitionProvider = true
+		result.Capabilities.DocumentSymbolProvider = true
+		return result, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		s.open(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.open(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+	case "textDocument/hover", "textDocument/definition", "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		switch req.Method {
+		case "textDocument/hover":
+			return s.hover(params), nil
+		case "textDocument/definition":
+			return s.definition(params), nil
+		default:
+			return s.completion(params), nil
+		}
+	case "textDocument/documentSymbol":
+		var params documentSymbolParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return s.symbols(params), nil
+	default:
+		if req.ID != nil {
+			return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+		}
+	}
+	return nil, nil
+}
+
+// Serve processes incoming messages until the exit notification is received.
+// It fails if the client exits without requesting a shutdown first.
+func (s *Server) Serve() error {
+	for {
+		content, err := s.read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed reading message")
+		}
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			if err := s.respond(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit without shutdown")
+			}
+			return nil
+		}
+		result, failure := s.handle(req)
+		if req.ID == nil {
+			continue
+		}
+		if err := s.respond(req.ID, result, failure); err != nil {
+			return errors.Wrap(err, "failed writing response")
+		}
+	}
+}
+
+// NewServer constructs a new server communicating using the given streams.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	ctx := runtime.NewContext()
+	operators.Load(ctx)
+	types.Load(ctx)
+	functions.Load(ctx)
+	return &Server{
+		reader:    bufio.NewReader(in),
+		writer:    out,
+		documents: make(map[string]*document),
+		builtins:  ctx.GlobalNamespace,
+	}
+}
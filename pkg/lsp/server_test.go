@@ -0,0 +1,93 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testProgram = `func double(x: int): int {
+    return x * 2;
+}
+let y = double(2);
+print(y);
+var z = 1;
+`
+
+func frame(messages ...string) io.Reader {
+	buf := &bytes.Buffer{}
+	for _, msg := range messages {
+		fmt.Fprintf(buf, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	}
+	return buf
+}
+
+func TestServer_Serve(t *testing.T) {
+	text, _ := json.Marshal(testProgram)
+	input := frame(
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"file:///a.tea","text":`+string(text)+`}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///a.tea"},"position":{"line":1,"character":11}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"textDocument/hover","params":{"textDocument":{"uri":"file:///a.tea"},"position":{"line":4,"character":1}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"textDocument/definition","params":{"textDocument":{"uri":"file:///a.tea"},"position":{"line":3,"character":9}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"textDocument/completion","params":{"textDocument":{"uri":"file:///a.tea"},"position":{"line":0,"character":0}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"textDocument/documentSymbol","params":{"textDocument":{"uri":"file:///a.tea"}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"unknown","params":{}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+	output := &bytes.Buffer{}
+	if err := NewServer(input, output).Serve(); err != nil {
+		t.Fatalf("Serve() unexpected error %v", err)
+	}
+
+	responses := make(map[string]string)
+	reader := &Server{reader: bufio.NewReader(output)}
+	for {
+		content, err := reader.read()
+		if err != nil {
+			break
+		}
+		var msg struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		json.Unmarshal(content, &msg)
+		if msg.Method != "" {
+			responses[msg.Method] = string(content)
+		} else {
+			responses[string(msg.ID)] = string(content)
+		}
+	}
+
+	tests := []struct {
+		key      string
+		contains []string
+	}{
+		{"1", []string{`"hoverProvider":true`, `"textDocumentSync":1`}},
+		{"textDocument/publishDiagnostics", []string{`"z is declared but never used"`, `"code":"prefer-let"`}},
+		{"2", []string{`"value":"x: int"`}},
+		{"3", []string{`"value":"print: func`}},
+		{"4", []string{`"range":{"start":{"line":0,"character":0}`}},
+		{"5", []string{`"label":"print"`, `"label":"double","kind":3`, `"label":"match"`, `"label":"+"`}},
+		{"6", []string{`"name":"double","kind":12`}},
+		{"7", []string{`"code":-32601`}},
+		{"8", []string{`"result":null`}},
+	}
+	for _, tt := range tests {
+		got, ok := responses[tt.key]
+		if !ok {
+			t.Errorf("missing response %s", tt.key)
+			continue
+		}
+		for _, want := range tt.contains {
+			if !strings.Contains(got, want) {
+				t.Errorf("response %s = %s, want to contain %s", tt.key, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package optimizer provides an optimization pass over abstract syntax trees of Tealang programs.
+package optimizer
+
+import (
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+type optimizer struct {
+	context    *runtime.Context
+	overloaded map[string]bool
+}
+
+// literal returns the value of the node if it is a literal.
+func literal(n nodes.Node) (runtime.Value, bool) {
+	lit, ok := n.(*nodes.Literal)
+	if !ok {
+		return runtime.Value{}, false
+	}
+	return lit.Value, true
+}
+
+// empty checks if the node is a sequence without any statements.
+func empty(n nodes.Node) bool {
+	seq, ok := n.(*nodes.Sequence)
+	return ok && len(seq.Childs) == 0
+}
+
+// substituted makes sure the node is evaluated in a substitute namespace when used outside of a conditional.
+func substituted(n nodes.Node) nodes.Node {
+	if seq, ok := n.(*nodes.Sequence); ok {
+		seq.Substitute = true
+		return seq
+	}
+	return nodes.NewSequence(true, n)
+}
+
+// fold evaluates operations on literals using built-in constant operators.
+// Operations failing at runtime are kept as they are.
+func (o *optimizer) fold(op *nodes.Operation) nodes.Node {
+	if o.overloaded[op.Symbol] {
+		return op
+	}
+	args := make([]runtime.Value, len(op.Childs))
+	for i, n := range op.Operands() {
+		value, ok := literal(n)
+		if !ok {
+			return op
+		}
+		args[i] = value
+	}
+	item, err := o.context.Namespace.Find(runtime.SearchOperator, op.Symbol)
+	if err != nil {
+		return op
+	}
+	operator, ok := item.(runtime.Operator)
+	// built-in operators do not have a source namespace
+	if !ok || !operator.Constant || operator.Source != nil {
+		return op
+	}
+	if operator.ShortCircuit != nil && len(args) > 1 {
+		if result, ok := operator.ShortCircuit(args[0]); ok {
+			return nodes.NewLiteral(result.Rechange(true))
+		}
+	}
+	scratch := &runtime.Context{
+		Namespace:       o.context.Namespace,
+		GlobalNamespace: o.context.GlobalNamespace,
+		Behavior:        runtime.BehaviorDefault,
+		Limits:          o.context.Limits,
+		Overflow:        o.context.Overflow,
+	}
+	result, err := operator.Eval(scratch, args)
+	if err != nil {
+		return op
+	}
+	return nodes.NewLiteral(result.Rechange(true))
+}
+
+// prune removes conditionals that can never be executed and everything following a conditional that is always executed.
+func (o *optimizer) prune(branch *nodes.Branch) nodes.Node {
+	childs := make([]nodes.Node, 0, len(branch.Childs))
+	for _, n := range branch.Childs {
+		cond, ok := n.(*nodes.Conditional)
+		if !ok {
+			childs = append(childs, n)
+			break
+		}
+		value, ok := literal(cond.Condition())
+		if !ok || value.Type != types.Bool {
+			childs = append(childs, n)
+			continue
+		}
+		if value.Data.(bool) {
+			childs = append(childs, substituted(cond.Body()))
+			break
+		}
+	}
+	if len(childs) == 0 {
+		return nodes.NewSequence(false)
+	}
+	if _, ok := childs[0].(*nodes.Conditional); !ok {
+		return childs[0]
+	}
+	branch.Childs = childs
+	return branch
+}
+
+// unroll removes loops whose condition is always false.
+func (o *optimizer) unroll(loop *nodes.Loop) nodes.Node {
+	value, ok := literal(loop.Condition())
+	if ok && value.Type == types.Bool && !value.Data.(bool) {
+		return nodes.NewSequence(false)
+	}
+	return loop
+}
+
+// compact removes empty sequences from the statement list.
+// The last statement is kept, since it determines the result of the sequence.
+func (o *optimizer) compact(seq *nodes.Sequence) nodes.Node {
+	childs := make([]nodes.Node, 0, len(seq.Childs))
+	for i, n := range seq.Childs {
+		if empty(n) && i < len(seq.Childs)-1 {
+			continue
+		}
+		childs = append(childs, n)
+	}
+	seq.Childs = childs
+	return seq
+}
+
+func (o *optimizer) rewrite(n nodes.Node) nodes.Node {
+	switch n := n.(type) {
+	case *nodes.Operation:
+		return o.fold(n)
+	case *nodes.Branch:
+		return o.prune(n)
+	case *nodes.Loop:
+		return o.unroll(n)
+	case *nodes.Sequence:
+		return o.compact(n)
+	}
+	return n
+}
+
+// Optimize folds constant operations, removes unreachable branches and empty sequences from the syntax tree.
+// The operators are looked up in the given context, operators defined within the tree are never folded.
+func Optimize(c *runtime.Context, tree nodes.Node) (nodes.Node, error) {
+	o := &optimizer{
+		context:    c,
+		overloaded: make(map[string]bool),
+	}
+	nodes.Inspect(tree, func(n nodes.Node) bool {
+		if def, ok := n.(*nodes.OperatorDefinition); ok {
+			o.overloaded[def.Symbol] = true
+		}
+		return true
+	})
+	return nodes.Rewrite(o.rewrite, tree)
+}
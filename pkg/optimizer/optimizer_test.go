@@ -0,0 +1,175 @@
+package optimizer
+
+import (
+	"testing"
+
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/functions"
+	"github.com/tealang/core/pkg/runtime/operators"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func newContext() *runtime.Context {
+	ctx := runtime.NewContext()
+	operators.Load(ctx)
+	types.Load(ctx)
+	functions.Load(ctx)
+	return ctx
+}
+
+func TestOptimize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		source  string
+		result  string
+		wantErr bool
+	}{
+		{
+			"Constant term",
+			"2 * 60 * 60;",
+			"7200;",
+			"7200",
+			false,
+		},
+		{
+			"Partially constant term",
+			"var x = 2; x * (3 + 4);",
+			"var x = 2;\nx * 7;",
+			"14",
+			false,
+		},
+		{
+			"Unary operators",
+			"-(1 + 2) * 2;",
+			"-6;",
+			"-6",
+			false,
+		},
+		{
+			"String concatenation",
+			`"a" + 1;`,
+			`"a1";`,
+			"a1",
+			false,
+		},
+		{
+			"Failing operation",
+			"1 / 0;",
+			"1 / 0;",
+			"",
+			true,
+		},
+		{
+			"Overloaded operator",
+			"operator %(a, b: int): int { return a + b; } 5 % 2;",
+			"operator %(a: int, b: int): int {\n    return a + b;\n}\n5 % 2;",
+			"",
+			true,
+		},
+		{
+			"Dead branch",
+			"if false { 1; } else { 2; }",
+			"{\n    2;\n}",
+			"2",
+			false,
+		},
+		{
+			"Always executed branch",
+			"var x = 1; if x == 2 { 1; } else if 1 < 2 { 3; } else { 2; }",
+			"var x = 1;\nif x == 2 {\n    1;\n} else {\n    3;\n}",
+			"3",
+			false,
+		},
+		{
+			"Eliminated branch",
+			"1; if false { 2; } 3;",
+			"1;\n3;",
+			"3",
+			false,
+		},
+		{
+			"Eliminated trailing branch",
+			"1; if false { 2; }",
+			"1;",
+			"",
+			false,
+		},
+		{
+			"Dead loop",
+			"var i = 0; for false { i = i + 1; } i;",
+			"var i = 0;\ni;",
+			"0",
+			false,
+		},
+		{
+			"Function body",
+			"func f(x: int): int { return x * (60 * 60); } f(2);",
+			"func f(x: int): int {\n    return x * 3600;\n}\nf(2);",
+			"7200",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results [2]string
+			for i, optimize := range []bool{false, true} {
+				ctx := newContext()
+				ast, _, err := parser.Parse(lexer.Lex(tt.input))
+				if err != nil {
+					t.Fatalf("Parse() unexpected error %v", err)
+				}
+				if optimize {
+					ast, err = Optimize(ctx, ast)
+					if err != nil {
+						t.Fatalf("Optimize() unexpected error %v", err)
+					}
+					if got := ast.Source(); got != tt.source {
+						t.Errorf("Optimize().Source() = %q, want %q", got, tt.source)
+					}
+				}
+				value, err := ast.Eval(ctx)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Eval() error = %v, wantErr %v (optimize = %t)", err, tt.wantErr, optimize)
+				}
+				if value.Type != nil {
+					results[i] = value.String()
+				}
+			}
+			if results[0] != tt.result || results[1] != tt.result {
+				t.Errorf("Eval() = %q (optimized %q), want %q", results[0], results[1], tt.result)
+			}
+		})
+	}
+}
+
+func TestOptimize_Overflow(t *testing.T) {
+	tests := []struct {
+		name string
+		mode runtime.OverflowMode
+		want string
+	}{
+		{"Check", runtime.OverflowCheck, "9223372036854775807 + 1;"},
+		{"Wrap", runtime.OverflowWrap, "-9223372036854775808;"},
+		{"Saturate", runtime.OverflowSaturate, "9223372036854775807;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newContext()
+			ctx.Overflow = tt.mode
+			ast, _, err := parser.Parse(lexer.Lex("9223372036854775807 + 1;"))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error %v", err)
+			}
+			ast, err = Optimize(ctx, ast)
+			if err != nil {
+				t.Fatalf("Optimize() unexpected error %v", err)
+			}
+			if got := ast.Source(); got != tt.want {
+				t.Errorf("Optimize().Source() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
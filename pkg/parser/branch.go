@@ -14,6 +14,7 @@ type branchParser struct {
 func (bp *branchParser) Parse(input []tokens.Token) (nodes.Node, int, error) {
 	bp.index, bp.size = 0, len(input)
 	for input[bp.index].Value == ifKeyword {
+		start := bp.index
 		condition, n, err := newTermParser().Parse(input[bp.index+1:])
 		if err != nil {
 			return bp.branch, bp.index, errors.Wrap(err, "can not parse condition")
@@ -26,7 +27,9 @@ func (bp *branchParser) Parse(input []tokens.Token) (nodes.Node, int, error) {
 		}
 		// skip right block and offset
 		bp.index += n + 2
-		bp.branch.AddBack(nodes.NewConditional(condition, stmt))
+		cond := nodes.NewConditional(condition, stmt)
+		locate(cond, input[start])
+		bp.branch.AddBack(cond)
 
 		if bp.index >= bp.size || input[bp.index].Value != elseKeyword {
 			return bp.branch, bp.index, nil
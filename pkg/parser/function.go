@@ -25,6 +25,13 @@ func (sp *parameterizedSequenceParser) fetch() tokens.Token {
 	return sp.active
 }
 
+// parameter generates a placeholder for the active parameter name.
+func (sp *parameterizedSequenceParser) parameter() nodes.Node {
+	param := nodes.NewLiteral(runtime.Value{Name: sp.active.Value})
+	locate(param, sp.active)
+	return param
+}
+
 func (sp *parameterizedSequenceParser) collectArgs() error {
 	if sp.fetch().Type != tokens.LeftParentheses {
 		return errors.Errorf("did expect left parentheses, got %s", sp.active.Type)
@@ -45,18 +52,19 @@ func (sp *parameterizedSequenceParser) collectArgs() error {
 					}
 					sp.index += offset - 1
 					for _, arg := range activeArgs {
-						sp.args = append(sp.args, nodes.NewType(typenode.(*nodes.Type).Tree, arg))
+						param := nodes.NewType(typenode.(*nodes.Type).Tree, arg)
+						line, column := nodes.Position(arg)
+						nodes.Locate(param, line, column)
+						sp.args = append(sp.args, param)
 					}
 					activeArgs = nil
 					expectType = false
 				} else {
-					activeArgs = append(activeArgs, nodes.NewLiteral(runtime.Value{Name: sp.active.Value}))
+					activeArgs = append(activeArgs, sp.parameter())
 				}
 			} else {
 				if !expectType {
-					activeArgs = []nodes.Node{
-						nodes.NewLiteral(runtime.Value{Name: sp.active.Value}),
-					}
+					activeArgs = []nodes.Node{sp.parameter()}
 				} else {
 					return errors.New("did not expect identifier")
 				}
@@ -86,6 +94,10 @@ func (sp *parameterizedSequenceParser) collectArgs() error {
 		}
 		sp.index += offset - 1
 		sp.returns = typenode.(*nodes.Type)
+		sp.fetch()
+	}
+	if sp.active.Type != tokens.LeftBlock {
+		return errors.Errorf("expected left block, got %s", sp.active.Type)
 	}
 
 	return nil
@@ -157,6 +169,7 @@ func (fp *functionParser) Parse(input []tokens.Token) (nodes.Node, int, error) {
 	}
 	fp.index += n
 	literal := nodes.NewFunctionLiteral(body, returns, args...)
+	locate(literal, input[0])
 	if fp.literal {
 		return literal, fp.index, nil
 	}
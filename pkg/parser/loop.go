@@ -50,7 +50,9 @@ func (lp *loopParser) Parse(input []tokens.Token) (nodes.Node, int, error) {
 	}
 	lp.index += n + 2
 
-	return nodes.NewSequence(true, head.Childs[0], nodes.NewLoop(head.Childs[1], nodes.NewSequence(false, body, head.Childs[2]))), lp.index, nil
+	loop := nodes.NewLoop(head.Childs[1], nodes.NewSequence(false, body, head.Childs[2]))
+	locate(loop, input[0])
+	return nodes.NewSequence(true, head.Childs[0], loop), lp.index, nil
 }
 
 func newLoopParser() *loopParser {
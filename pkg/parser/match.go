@@ -66,10 +66,12 @@ func (mp *matchParser) Parse(input []tokens.Token) (nodes.Node, int, error) {
 
 	mp.cases = nil
 	for mp.index < mp.size && input[mp.index].Type == tokens.Identifier && input[mp.index].Value == caseKeyword {
+		start := input[mp.index]
 		mp.index++
 		if err := mp.parseCase(input, false); err != nil {
 			return nil, mp.index, errors.Errorf("failed to build match: %v", err)
 		}
+		locate(mp.cases[len(mp.cases)-1], start)
 	}
 
 	if mp.index < mp.size && input[mp.index].Type == tokens.Identifier && input[mp.index].Value == defaultKeyword {
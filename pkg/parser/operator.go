@@ -23,7 +23,8 @@ func (op *operatorParser) fetch() tokens.Token {
 }
 
 func (op *operatorParser) assignSymbol() error {
-	if op.fetch().Type != tokens.Operator {
+	// operators spelled like identifiers, e.g. xor, can be overloaded as well
+	if op.fetch().Type != tokens.Operator && (op.active.Type != tokens.Identifier || op.active.Value != xorOperator) {
 		return errors.Errorf("expected operator symbol, got %s", op.active.Type)
 	}
 	op.symbol = op.active.Value
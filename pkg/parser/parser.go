@@ -26,16 +26,25 @@ const (
 	caseKeyword        = "case"
 	castOperator       = ":"
 	assignmentOperator = "="
+	memberOperator     = "."
+	xorOperator        = "xor"
 )
 
+// locate stores the token position as the node origin, unless the node has already been located.
+func locate(node nodes.Node, tk tokens.Token) {
+	if line, _ := nodes.Position(node); line == 0 {
+		nodes.Locate(node, tk.Line, tk.Column)
+	}
+}
+
 // Parse generates an abstract syntax tree from the given list of tokens.
 // It returns the generated tree node, the parsed token offset and in the case of a failure,
 // an error object.
 func Parse(input []tokens.Token) (nodes.Node, int, error) {
-	// clean input from whitespace
+	// clean input from whitespace and comments
 	cleaned := make([]tokens.Token, 0, len(input))
 	for _, tk := range input {
-		if tk.Type != tokens.Whitespace {
+		if tk.Type != tokens.Whitespace && tk.Type != tokens.SingleLineComment {
 			cleaned = append(cleaned, tk)
 		}
 	}
@@ -0,0 +1,320 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/optimizer"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/functions"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/operators"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+var sourceTests = []struct {
+	name  string
+	input string
+	want  string
+}{
+	{
+		"Term",
+		"1 + 2 * 3;",
+		"1 + (2 * 3);",
+	},
+	{
+		"Unary operators",
+		"-x + !y;",
+		"-x + !y;",
+	},
+	{
+		"Literals",
+		`let a, b, c, d = "tea", 1.0, true, null;`,
+		`let a, b, c, d = "tea", 1.0, true, null;`,
+	},
+	{
+		"Arbitrary-precision literals",
+		"let a, b = 123456789012345678901234567890n, 19.990d;",
+		"let a, b = 123456789012345678901234567890n, 19.99d;",
+	},
+	{
+		"Bitwise operators",
+		"a | b & c xor ~d << 2; x <<= 1; x |= y >> 1;",
+		"(a | (b & c)) xor (~d << 2);\nx <<= 1;\nx |= y >> 1;",
+	},
+	{
+		"Xor as identifier",
+		"let xor = 1; xor(xor) xor -xor; x = xor xor xor;",
+		"let xor = 1;\nxor(xor) xor -xor;\nx = xor xor xor;",
+	},
+	{
+		"Xor operator definition",
+		"operator xor(a, b: string): string { return a + b; }",
+		"operator xor(a: string, b: string): string {\n    return a + b;\n}",
+	},
+	{
+		"Power and floor division",
+		"a ^ b ^ c // d; x ^= 2; x //= 3;",
+		"(a ^ (b ^ c)) // d;\nx ^= 2;\nx //= 3;",
+	},
+	{
+		"Logical and null coalescing operators",
+		"a && b || c ?? d;",
+		"((a && b) || c) ?? d;",
+	},
+	{
+		"Nested type parameters",
+		"var x: array<array<int>>, y: map<array<array<int>>>;",
+		"var x: array<array<int>>, y: map<array<array<int>>>;",
+	},
+	{
+		"Typed declaration",
+		"var x: float, y: int;",
+		"var x: float, y: int;",
+	},
+	{
+		"Typed declaration with assignment",
+		"var x: int = 3;",
+		"var x: int = 3;",
+	},
+	{
+		"Assignment",
+		"x, y = 1, 2; x += 3;",
+		"x, y = 1, 2;\nx += 3;",
+	},
+	{
+		"Function",
+		"func f(a, b: int): int { return a + b; } f(1, 2);",
+		"func f(a: int, b: int): int {\n    return a + b;\n}\nf(1, 2);",
+	},
+	{
+		"Qualified names",
+		`let s = fs.read_file(path.join(dir, "a.txt"));`,
+		`let s = fs.read_file(path.join(dir, "a.txt"));`,
+	},
+	{
+		"Function literal",
+		"let g = func(a: int) { return a; }; g(5);",
+		"func g(a: int) {\n    return a;\n}\ng(5);",
+	},
+	{
+		"Operator",
+		"operator /?(a, b: int): bool { return a % b == 0; }",
+		"operator /?(a: int, b: int): bool {\n    return (a % b) == 0;\n}",
+	},
+	{
+		"Branch",
+		"if a { 1; } else if b { 2; } else { 3; }",
+		"if a {\n    1;\n} else if b {\n    2;\n} else {\n    3;\n}",
+	},
+	{
+		"If expression",
+		"let x = 1 + if a { 1; } else { 2; };",
+		"let x = 1 + (if a {\n    1;\n} else {\n    2;\n});",
+	},
+	{
+		"Loop",
+		"for i < 3 { i = i + 1; continue; }",
+		"for i < 3 {\n    i = i + 1;\n    continue;\n}",
+	},
+	{
+		"C-style loop",
+		"for var i = 0; i < 3; i = i + 1 { break; }",
+		"for var i = 0; i < 3; i = i + 1 {\n    break;\n}",
+	},
+	{
+		"Match",
+		"match x { case 1 { fallthrough; } case 2 {} default { 3; } }",
+		"match x {\n    case 1 {\n        fallthrough;\n    }\n    case 2 {}\n    default {\n        3;\n    }\n}",
+	},
+	{
+		"Block",
+		"{ let x = 1; }",
+		"{\n    let x = 1;\n}",
+	},
+}
+
+func TestParse_Source(t *testing.T) {
+	for _, tt := range sourceTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ast, _, err := Parse(lexer.Lex(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error %v", err)
+			}
+			got := ast.Source()
+			if got != tt.want {
+				t.Errorf("Parse().Source() = %q, want %q", got, tt.want)
+			}
+			reparsed, _, err := Parse(lexer.Lex(got))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error on generated source %v", err)
+			}
+			if again := reparsed.Source(); again != got {
+				t.Errorf("Parse().Source() not stable, got %q, want %q", again, got)
+			}
+		})
+	}
+}
+
+func TestParse_IfExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Missing else", "let x = if a { 1; };"},
+		{"Incompatible arms", `let x = if a { 1; } else if b { 2; } else { "tea"; };`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Parse(lexer.Lex(tt.input)); err == nil {
+				t.Errorf("Parse() expected error")
+			}
+		})
+	}
+}
+
+var evalTests = []struct {
+	name    string
+	input   string
+	want    string
+	wantErr bool
+}{
+	{"Term", "1 + 2 * 3;", "7", false},
+	{"Division by zero", "1 / 0;", "", true},
+	{"Loop", "var i = 0; for i < 3 { i = i + 1; } i;", "3", false},
+	{"C-style loop", "var s = 0; for var i = 0; i < 4; i = i + 1 { s = s + i; } s;", "6", false},
+	{"Function", "func f(a, b: int): int { return a * b; } f(6, 7);", "42", false},
+	{"Operator", "operator /?(a, b: int): bool { return a % b == 0; } 9 /? 3;", "true", false},
+	{"Match", "let x = 2; match x { case 1 { 10; } case 2 { 20; } default { 30; } }", "20", false},
+	{"Constant branch", "if 1 < 2 { 1; } else { 2; }", "1", false},
+	{"Xor operator", "6 xor 3 xor (1 xor 1);", "5", false},
+	{"Xor variable", "let xor = 6; xor xor xor;", "0", false},
+	{"Xor function", "func xor(a, b: int): int { return a + b; } xor(1, 2) xor 1;", "2", false},
+	{"Short-circuit and", "let x = 0; 0 != x && 10 / x > 1;", "false", false},
+	{"Short-circuit or", "let x = 0; x == 0 || 10 / x > 1;", "true", false},
+	{"Null coalescing", "let x = null; x ?? 1 ?? 10 / 0;", "1", false},
+	{"Null coalescing non-null", `let x = "tea"; x ?? 10 / 0;`, "tea", false},
+	{"Null coalescing literals", "1 ?? 2;", "1", false},
+	{"Null coalescing nulls", "null ?? null;", "", false},
+	{"If expression", `let x = 2; let y = if x > 1 { "big"; } else { "small"; }; y;`, "big", false},
+	{"If expression in term", "let x = 0; 1 + if x > 1 { 10; } else if x > 0 { 20; } else { var y = 15; y * 2; };", "31", false},
+	{"If expression with incompatible arms", `let c = true; let y = if c { 1; } else { "a"; };`, "", true},
+	{"If expression with incompatible identifier arm", `let c, x = true, 1; let y = if c { x; } else { "a"; };`, "", true},
+	{"Equal arrays", `let a, b = json.parse("[1,2]"), json.parse("[1,2]"); a == b;`, "true", false},
+	{"Array access", `let a = json.parse("[1,[2,3]]"); get(get(a, 1), 0) + len(a);`, "4", false},
+	{"Null bigint", "var x: bigint; x;", "null", false},
+	{"Null decimal", "var x: decimal; x == null;", "true", false},
+	{"Null bigint arithmetic", "var x: bigint; x + 1n;", "", true},
+	{
+		"Stack trace of caught error",
+		`func load(n: string) { return fs.read_file(n); } stack_trace(load("a"));`,
+		"stack trace:\n    fs.read_file(name: string)\n    load(n: string) at line 1, column 31\n    main at line 1, column 62\n",
+		false,
+	},
+	{"Stack trace of plain error", `stack_trace(null: error);`, "", false},
+	{"Module member", `let path = "a/b.tea"; path.base(path);`, "b.tea", false},
+	{"Missing module member", `path.missing("a");`, "", true},
+}
+
+// evaluate executes the program in a sandboxed context with all builtins loaded.
+// If optimize is set, the syntax tree is optimized before being executed.
+func evaluate(input string, optimize bool) (string, error) {
+	ctx := runtime.NewContext()
+	ctx.System = &runtime.Sandbox{}
+	operators.Load(ctx)
+	types.Load(ctx)
+	functions.Load(ctx)
+	ast, _, err := Parse(lexer.Lex(input))
+	if err != nil {
+		return "", err
+	}
+	if optimize {
+		if ast, err = optimizer.Optimize(ctx, ast); err != nil {
+			return "", err
+		}
+	}
+	value, err := nodes.Evaluate(ctx, ast)
+	if err != nil || value.Type == nil {
+		return "", err
+	}
+	return value.String(), nil
+}
+
+func TestParse_Eval(t *testing.T) {
+	for _, tt := range evalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluate(tt.input, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("evaluate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParse_Optimize runs the source and evaluation cases with and without the optimizer,
+// both runs must yield the same result.
+// optimizeTests are programs the optimizer folds, source is the optimized syntax tree.
+var optimizeTests = []struct {
+	name   string
+	input  string
+	source string
+	want   string
+}{
+	{"Constant term", "1 + 2 * 3;", "7;", "7"},
+	{"Constant comparison", "2 * 3 > 5 == true;", "true;", "true"},
+	{"Partial term", "let x = 4; x * (2 + 3);", "let x = 4;\nx * 5;", "20"},
+	{"Dead else arm", "if 1 < 2 { 1; } else { 2; }", "{\n    1;\n}", "1"},
+	{"Dead if arm", "if false { 1; } else if true { 2; } else { 3; }", "{\n    2;\n}", "2"},
+	{"Null coalescing", "null ?? 2;", "2;", "2"},
+	{"Null coalescing non-null", "1 ?? 2;", "1;", "1"},
+	{"Short-circuit and", "false && true;", "false;", "false"},
+	{"Short-circuit or", "1 < 2 || 2 > 3;", "true;", "true"},
+}
+
+func TestParse_Optimize(t *testing.T) {
+	for _, tt := range optimizeTests {
+		t.Run("Fold/"+tt.name, func(t *testing.T) {
+			ctx := runtime.NewContext()
+			operators.Load(ctx)
+			types.Load(ctx)
+			functions.Load(ctx)
+			ast, _, err := Parse(lexer.Lex(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() unexpected error %v", err)
+			}
+			if ast, err = optimizer.Optimize(ctx, ast); err != nil {
+				t.Fatalf("Optimize() unexpected error %v", err)
+			}
+			if got := ast.Source(); got != tt.source {
+				t.Errorf("Optimize().Source() = %q, want %q", got, tt.source)
+			}
+			for _, optimize := range []bool{false, true} {
+				got, err := evaluate(tt.input, optimize)
+				if err != nil {
+					t.Fatalf("evaluate() optimize = %v, unexpected error %v", optimize, err)
+				}
+				if got != tt.want {
+					t.Errorf("evaluate() optimize = %v, got %q, want %q", optimize, got, tt.want)
+				}
+			}
+		})
+	}
+	for _, tt := range evalTests {
+		t.Run("Eval/"+tt.name, func(t *testing.T) {
+			want, wantErr := evaluate(tt.input, false)
+			if (wantErr != nil) != tt.wantErr {
+				t.Fatalf("evaluate() unoptimized error = %v, wantErr %v", wantErr, tt.wantErr)
+			}
+			got, err := evaluate(tt.input, true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("evaluate() optimized error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != want {
+				t.Errorf("evaluate() optimized = %q, unoptimized = %q", got, want)
+			}
+		})
+	}
+}
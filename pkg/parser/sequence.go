@@ -170,9 +170,13 @@ func (sp *sequenceParser) Parse(input []tokens.Token) (nodes.Node, int, error) {
 			if !ok {
 				handler = sp.handleTerm
 			}
+			start, count := sp.active, len(sp.sequence.Childs)
 			if err := handler(); err != nil {
 				return sp.sequence, sp.index, errors.Wrapf(err, "failed handling token %s", sp.active.Type.Name)
 			}
+			for _, stmt := range sp.sequence.Childs[count:] {
+				locate(stmt, start)
+			}
 		}
 		if sp.cap != 0 && len(sp.sequence.Childs) >= sp.cap {
 			return sp.sequence, sp.index, nil
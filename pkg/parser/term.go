@@ -41,7 +41,7 @@ type termParser struct {
 
 func (tp *termParser) binding(item termItem) bool {
 	switch item.Value.Value {
-	case "^", "!":
+	case "^", "!", "~":
 		return true
 	case "+", "-":
 		if tp.isUnaryOperator(item) {
@@ -65,7 +65,7 @@ func (termParser) isUnaryOperator(item termItem) bool {
 		case nil, tokens.Operator:
 			return true
 		}
-	case "!", ":":
+	case "!", "~", ":":
 		return true
 	}
 	return false
@@ -73,14 +73,14 @@ func (termParser) isUnaryOperator(item termItem) bool {
 
 func (tp *termParser) priority(item termItem) int {
 	switch item.Value.Value {
-	case "&", "|":
-		return 8
-	case "!":
+	case "!", "~":
 		return 7
 	case "^":
 		return 6
-	case "*", "/":
+	case "*", "/", "//", "<<", ">>", "&":
 		return 5
+	case "|", xorOperator:
+		return 4
 	case "+", "-":
 		if tp.isUnaryOperator(item) {
 			return 9
@@ -92,7 +92,7 @@ func (tp *termParser) priority(item termItem) int {
 		return 3
 	case "<", ">", ">=", "<=", "=<", "!=", "==":
 		return 2
-	case "&&", "||", "^|":
+	case "&&", "||", "^|", "??":
 		return 1
 	case "=>":
 		return 0
@@ -102,6 +102,9 @@ func (tp *termParser) priority(item termItem) int {
 }
 
 func (tp *termParser) itemFromActive(node nodes.Node) termItem {
+	if node != nil {
+		locate(node, tp.active)
+	}
 	return termItem{
 		Value:    tp.active,
 		Next:     tp.next,
@@ -110,7 +113,26 @@ func (tp *termParser) itemFromActive(node nodes.Node) termItem {
 	}
 }
 
+// isWordOperator checks if the active identifier is an operator spelled like an identifier, e.g. xor.
+// It is only an operator if it follows an operand, otherwise it names a variable or function.
+func (tp *termParser) isWordOperator() bool {
+	if tp.active.Value != xorOperator {
+		return false
+	}
+	switch tp.previous.Type {
+	case tokens.Identifier, tokens.Number, tokens.String, tokens.RightParentheses:
+		return true
+	}
+	return false
+}
+
 func (tp *termParser) handleIdentifier() error {
+	if tp.isWordOperator() {
+		// the token is retyped in place, so following unary operators see an operator before them
+		tp.active.Type = tokens.Operator
+		tp.input[tp.index] = tp.active
+		return tp.handleOperator()
+	}
 	switch tp.active.Value {
 	case trueKeyword:
 		tp.output.Push(tp.itemFromActive(nodes.NewLiteral(types.True)))
@@ -123,20 +145,57 @@ func (tp *termParser) handleIdentifier() error {
 		if err != nil {
 			return errors.Wrap(err, "failed to parse function")
 		}
-		tp.index += n
+		// the loop increments past the closing block
+		tp.index += n - 1
 		tp.output.Push(tp.itemFromActive(literal))
+	case ifKeyword:
+		node, n, err := newBranchParser().Parse(tp.input[tp.index:])
+		if err != nil {
+			return errors.Wrap(err, "failed to parse if expression")
+		}
+		branch := node.(*nodes.Branch)
+		if branch.Else() == nil {
+			return errors.New("if expression requires an else branch")
+		}
+		branch.Expression = true
+		if err := branch.Typecheck(); err != nil {
+			return err
+		}
+		// the loop increments past the closing block
+		tp.index += n - 1
+		tp.output.Push(tp.itemFromActive(branch))
 	default:
+		start := tp.active
+		module, alias := tp.member()
 		if tp.next.Type == tokens.LeftParentheses {
-			alias := tp.active.Value
+			call := nodes.NewFunctionCall(alias)
+			call.Module = module
+			locate(call, start)
 			tp.fetch(true)
-			tp.operators.Push(tp.itemFromActive(nodes.NewFunctionCall(alias)))
+			tp.operators.Push(tp.itemFromActive(call))
 			return nil
 		}
-		tp.output.Push(tp.itemFromActive(nodes.NewIdentifier(tp.active.Value)))
+		identifier := nodes.NewIdentifier(alias)
+		identifier.Module = module
+		locate(identifier, start)
+		tp.output.Push(tp.itemFromActive(identifier))
 	}
 	return nil
 }
 
+// member splits a member access like fs.read_file into the module and the alias of the member.
+// Plain identifiers have no module.
+func (tp *termParser) member() (string, string) {
+	if tp.next.Type != tokens.Operator || tp.next.Value != memberOperator ||
+		tp.index+2 >= tp.size || tp.input[tp.index+2].Type != tokens.Identifier {
+		return "", tp.active.Value
+	}
+	module := tp.active.Value
+	tp.fetch(true)
+	tp.fetch(true)
+	return module, tp.active.Value
+}
+
 func (tp *termParser) handleString() error {
 	tp.output.Push(tp.itemFromActive(nodes.NewLiteral(runtime.Value{
 		Typeflag: runtime.T(types.String),
@@ -146,7 +205,35 @@ func (tp *termParser) handleString() error {
 	return nil
 }
 
+// Number literal suffixes of arbitrary-precision numbers.
+const (
+	bigIntSuffix  = "n"
+	decimalSuffix = "d"
+)
+
 func (tp *termParser) handleNumber() error {
+	var (
+		text     = tp.active.Value
+		datatype *runtime.Datatype
+	)
+	switch {
+	case strings.HasSuffix(text, bigIntSuffix):
+		text, datatype = strings.TrimSuffix(text, bigIntSuffix), types.BigInt
+		if strings.Contains(text, ".") {
+			return errors.Errorf("bigint literal %s can not have a fraction", tp.active.Value)
+		}
+	case strings.HasSuffix(text, decimalSuffix):
+		text, datatype = strings.TrimSuffix(text, decimalSuffix), types.Decimal
+	}
+	if datatype != nil {
+		value, err := datatype.Cast(runtime.Value{Typeflag: runtime.T(types.String), Data: text}, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s literal", datatype)
+		}
+		value.Constant = true
+		tp.output.Push(tp.itemFromActive(nodes.NewLiteral(value)))
+		return nil
+	}
 	if strings.Contains(tp.active.Value, ".") {
 		f, err := strconv.ParseFloat(tp.active.Value, 64)
 		if err != nil {
@@ -175,12 +262,14 @@ func (tp *termParser) handleOperator() error {
 	item := tp.itemFromActive(nil)
 	if tp.active.Value != ":" || tp.next.Type != tokens.Identifier {
 		item.Node = nodes.NewOperation(tp.active.Value, tp.argCount(item))
+		locate(item.Node, tp.active)
 	} else {
 		typenode, offset, err := newTypeParser().Parse(tp.input[tp.index+1:])
 		if err != nil {
 			return err
 		}
 		item.Node = typenode
+		locate(typenode, tp.active)
 		tp.index += offset
 		tp.fetch(false)
 	}
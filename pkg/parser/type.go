@@ -1,15 +1,19 @@
 package parser
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/lexer/tokens"
 	"github.com/tealang/core/pkg/runtime/nodes"
 )
 
 type typeParser struct {
-	index, size int
-	input       []tokens.Token
-	active, next      tokens.Token
+	index, size  int
+	input        []tokens.Token
+	active, next tokens.Token
+	// closed counts the brackets of enclosing trees closed by a shift operator token like >>
+	closed int
 }
 
 func (tp *typeParser) fetch() tokens.Token {
@@ -43,7 +47,12 @@ func (tp *typeParser) tree() (nodes.Typetree, error) {
 			return tree, err
 		}
 		tree.Params = append(tree.Params, subtree)
-		if tp.fetch().Type == tokens.Operator && tp.active.Value == ">" {
+		if tp.closed > 0 {
+			tp.closed--
+			return tree, nil
+		}
+		if tp.fetch().Type == tokens.Operator && strings.Trim(tp.active.Value, ">") == "" {
+			tp.closed = len(tp.active.Value) - 1
 			return tree, nil
 		}
 		if tp.active.Type != tokens.Separator {
@@ -0,0 +1,158 @@
+package profiler
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// Field numbers of the pprof profile.proto messages used by the encoder.
+const (
+	profileSampleType    = 1
+	profileSample        = 2
+	profileLocation      = 4
+	profileFunction      = 5
+	profileStringTable   = 6
+	profileDurationNanos = 10
+	profilePeriodType    = 11
+	profilePeriod        = 12
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	sampleLocationID = 1
+	sampleValue      = 2
+
+	locationID   = 1
+	locationLine = 4
+
+	lineFunctionID = 1
+	lineLine       = 2
+
+	functionID       = 1
+	functionName     = 2
+	functionFilename = 4
+)
+
+// buffer encodes protocol buffer messages.
+type buffer struct {
+	data []byte
+}
+
+func (b *buffer) varint(x uint64) {
+	for x >= 0x80 {
+		b.data = append(b.data, byte(x)|0x80)
+		x >>= 7
+	}
+	b.data = append(b.data, byte(x))
+}
+
+func (b *buffer) key(field, wire int) {
+	b.varint(uint64(field)<<3 | uint64(wire))
+}
+
+func (b *buffer) int(field int, x int64) {
+	if x == 0 {
+		return
+	}
+	b.key(field, 0)
+	b.varint(uint64(x))
+}
+
+func (b *buffer) bytes(field int, data []byte) {
+	b.key(field, 2)
+	b.varint(uint64(len(data)))
+	b.data = append(b.data, data...)
+}
+
+func (b *buffer) message(field int, encode func(m *buffer)) {
+	m := &buffer{}
+	encode(m)
+	b.bytes(field, m.data)
+}
+
+func (b *buffer) packed(field int, xs []int64) {
+	b.message(field, func(m *buffer) {
+		for _, x := range xs {
+			m.varint(uint64(x))
+		}
+	})
+}
+
+// stringTable assigns indices to strings, the empty string always has index zero.
+type stringTable struct {
+	strings []string
+	indices map[string]int64
+}
+
+func (t *stringTable) index(s string) int64 {
+	if i, ok := t.indices[s]; ok {
+		return i
+	}
+	i := int64(len(t.strings))
+	t.strings = append(t.strings, s)
+	t.indices[s] = i
+	return i
+}
+
+// WriteProfile writes the samples as a gzip-compressed pprof profile readable by `go tool pprof`.
+// Each function and line combination is a location, the program file is used as file name.
+func (p *Profiler) WriteProfile(w io.Writer, filename string) error {
+	table := &stringTable{strings: []string{""}, indices: map[string]int64{"": 0}}
+	functions := make(map[string]int64)
+	locations := make(map[location]int64)
+	out := &buffer{}
+
+	valueType := func(field int, kind, unit string) {
+		out.message(field, func(m *buffer) {
+			m.int(valueTypeType, table.index(kind))
+			m.int(valueTypeUnit, table.index(unit))
+		})
+	}
+	valueType(profileSampleType, "samples", "count")
+	valueType(profileSampleType, "time", "nanoseconds")
+
+	for _, s := range p.sorted() {
+		ids := make([]int64, len(s.stack))
+		for i, loc := range s.stack {
+			id, ok := locations[loc]
+			if !ok {
+				if _, ok := functions[loc.function]; !ok {
+					functions[loc.function] = int64(len(functions) + 1)
+				}
+				id = int64(len(locations) + 1)
+				locations[loc] = id
+				out.message(profileLocation, func(m *buffer) {
+					m.int(locationID, id)
+					m.message(locationLine, func(l *buffer) {
+						l.int(lineFunctionID, functions[loc.function])
+						l.int(lineLine, int64(loc.line))
+					})
+				})
+			}
+			ids[i] = id
+		}
+		out.message(profileSample, func(m *buffer) {
+			m.packed(sampleLocationID, ids)
+			m.packed(sampleValue, []int64{int64(s.count), int64(s.time)})
+		})
+	}
+	for name, id := range functions {
+		out.message(profileFunction, func(m *buffer) {
+			m.int(functionID, id)
+			m.int(functionName, table.index(name))
+			m.int(functionFilename, table.index(filename))
+		})
+	}
+	out.int(profileDurationNanos, int64(p.total()))
+	valueType(profilePeriodType, "time", "nanoseconds")
+	out.int(profilePeriod, 1)
+	for _, s := range table.strings {
+		out.bytes(profileStringTable, []byte(s))
+	}
+
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(out.data); err != nil {
+		return err
+	}
+	return gz.Close()
+}
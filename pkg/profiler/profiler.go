@@ -0,0 +1,248 @@
+// Package profiler provides tracers measuring and logging the execution of Tealang programs.
+package profiler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+)
+
+// mainFunction is the name used for code running outside of any function.
+const mainFunction = "main"
+
+// Entry is the measurement of a function or line.
+// Flat time is spent in the entry itself, cumulative time includes everything called by it.
+type Entry struct {
+	Name             string
+	Count            int
+	Flat, Cumulative time.Duration
+}
+
+// location is a line within a function.
+type location struct {
+	function string
+	line     int
+}
+
+// sample is the time spent in a call stack, the innermost location comes first.
+type sample struct {
+	stack []location
+	count int
+	time  time.Duration
+}
+
+// Profiler is a runtime tracer measuring evaluation counts and time per function and line.
+type Profiler struct {
+	now       func() time.Time
+	last      time.Time
+	lines     []int
+	starts    []time.Time
+	calls     []time.Time
+	active    map[string]int
+	activeAt  map[int]int
+	functions map[string]*Entry
+	positions map[int]*Entry
+	samples   map[string]*sample
+}
+
+// account attributes the time since the last event to the current location.
+func (p *Profiler) account(c *runtime.Context) {
+	now := p.now()
+	elapsed := time.Duration(0)
+	if !p.last.IsZero() {
+		elapsed = now.Sub(p.last)
+	}
+	p.last = now
+
+	line := 0
+	if len(p.lines) > 0 {
+		line = p.lines[len(p.lines)-1]
+	}
+	stack := make([]location, 0, len(c.Stack)+1)
+	for i := len(c.Stack) - 1; i >= 0; i-- {
+		stack = append(stack, location{c.Stack[i].Function, line})
+		line = c.Stack[i].Line
+	}
+	stack = append(stack, location{mainFunction, line})
+
+	keys := make([]string, len(stack))
+	for i, loc := range stack {
+		keys[i] = fmt.Sprintf("%s:%d", loc.function, loc.line)
+	}
+	key := strings.Join(keys, ";")
+	s, ok := p.samples[key]
+	if !ok {
+		s = &sample{stack: stack}
+		p.samples[key] = s
+	}
+	s.count++
+	s.time += elapsed
+	p.function(stack[0].function).Flat += elapsed
+	p.line(stack[0].line).Flat += elapsed
+}
+
+func (p *Profiler) function(name string) *Entry {
+	e, ok := p.functions[name]
+	if !ok {
+		e = &Entry{Name: name}
+		p.functions[name] = e
+	}
+	return e
+}
+
+func (p *Profiler) line(line int) *Entry {
+	e, ok := p.positions[line]
+	if !ok {
+		e = &Entry{Name: fmt.Sprintf("line %d", line)}
+		p.positions[line] = e
+	}
+	return e
+}
+
+// Enter starts measuring the node, nodes without position are attributed to the line of their parent.
+func (p *Profiler) Enter(c *runtime.Context, n runtime.Evaluable) {
+	p.account(c)
+	line := 0
+	if len(p.lines) > 0 {
+		line = p.lines[len(p.lines)-1]
+	}
+	if node, ok := n.(nodes.Node); ok {
+		if l, _ := nodes.Position(node); l > 0 {
+			line = l
+			p.line(line).Count++
+		}
+	}
+	p.lines = append(p.lines, line)
+	p.starts = append(p.starts, p.last)
+	p.activeAt[line]++
+}
+
+// Leave stops measuring the node. The cumulative time of a line is only increased by its outermost node.
+func (p *Profiler) Leave(c *runtime.Context, n runtime.Evaluable, result runtime.Value, err error) {
+	p.account(c)
+	last := len(p.lines) - 1
+	line, start := p.lines[last], p.starts[last]
+	p.lines, p.starts = p.lines[:last], p.starts[:last]
+	if p.activeAt[line]--; p.activeAt[line] == 0 {
+		p.line(line).Cumulative += p.last.Sub(start)
+	}
+}
+
+// Call starts measuring the function invocation.
+func (p *Profiler) Call(c *runtime.Context, frame runtime.Frame) {
+	p.account(c)
+	p.function(frame.Function).Count++
+	p.calls = append(p.calls, p.last)
+	p.active[frame.Function]++
+}
+
+// Return stops measuring the function invocation. Recursive calls only count once towards the cumulative time.
+func (p *Profiler) Return(c *runtime.Context, frame runtime.Frame, result runtime.Value, err error) {
+	p.account(c)
+	last := len(p.calls) - 1
+	start := p.calls[last]
+	p.calls = p.calls[:last]
+	if p.active[frame.Function]--; p.active[frame.Function] == 0 {
+		p.function(frame.Function).Cumulative += p.last.Sub(start)
+	}
+}
+
+// total returns the measured time.
+func (p *Profiler) total() time.Duration {
+	var sum time.Duration
+	for _, s := range p.samples {
+		sum += s.time
+	}
+	return sum
+}
+
+// sorted lists the samples ordered by their call stack.
+func (p *Profiler) sorted() []*sample {
+	keys := make([]string, 0, len(p.samples))
+	for key := range p.samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	samples := make([]*sample, len(keys))
+	for i, key := range keys {
+		samples[i] = p.samples[key]
+	}
+	return samples
+}
+
+// Functions lists the measurements of the functions, ordered by descending cumulative time.
+// Code outside of functions is attributed to the main function.
+func (p *Profiler) Functions() []Entry {
+	entries := make([]Entry, 0, len(p.functions))
+	for _, e := range p.functions {
+		entry := *e
+		if entry.Name == mainFunction {
+			entry.Cumulative = p.total()
+		}
+		entries = append(entries, entry)
+	}
+	return top(entries)
+}
+
+// Lines lists the measurements of the lines, ordered by descending cumulative time.
+// Time spent outside of any located node is left out.
+func (p *Profiler) Lines() []Entry {
+	entries := make([]Entry, 0, len(p.positions))
+	for line, e := range p.positions {
+		if line > 0 {
+			entries = append(entries, *e)
+		}
+	}
+	return top(entries)
+}
+
+func top(entries []Entry) []Entry {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Cumulative != entries[j].Cumulative {
+			return entries[i].Cumulative > entries[j].Cumulative
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// Report writes a text report of the top n functions and lines.
+func (p *Profiler) Report(w io.Writer, n int) {
+	total := p.total()
+	percent := func(d time.Duration) float64 {
+		if total == 0 {
+			return 0
+		}
+		return 100 * float64(d) / float64(total)
+	}
+	table := func(title string, entries []Entry) {
+		fmt.Fprintf(w, "%s:\n", title)
+		fmt.Fprintf(w, "%12s %7s %12s %7s %8s  %s\n", "flat", "flat%", "cum", "cum%", "count", "name")
+		for i, e := range entries {
+			if i >= n {
+				break
+			}
+			fmt.Fprintf(w, "%12s %6.2f%% %12s %6.2f%% %8d  %s\n", e.Flat, percent(e.Flat), e.Cumulative, percent(e.Cumulative), e.Count, e.Name)
+		}
+	}
+	fmt.Fprintf(w, "total: %s\n", total)
+	table("functions", p.Functions())
+	table("lines", p.Lines())
+}
+
+// New constructs a new profiler.
+func New() *Profiler {
+	return &Profiler{
+		now:       time.Now,
+		active:    make(map[string]int),
+		activeAt:  make(map[int]int),
+		functions: make(map[string]*Entry),
+		positions: make(map[int]*Entry),
+		samples:   make(map[string]*sample),
+	}
+}
@@ -0,0 +1,80 @@
+package profiler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/functions"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/operators"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+const testProgram = `func fib(n: int): int {
+    if n < 2 {
+        return n;
+    }
+    return fib(n - 1) + fib(n - 2);
+}
+let x = fib(5);
+`
+
+func TestProfiler(t *testing.T) {
+	p := New()
+	clock := time.Unix(0, 0)
+	p.now = func() time.Time {
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+	ctx := runtime.NewContext()
+	operators.Load(ctx)
+	types.Load(ctx)
+	functions.Load(ctx)
+	ctx.Tracer = p
+	ast, _, err := parser.Parse(lexer.Lex(testProgram))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error %v", err)
+	}
+	if _, err := nodes.Evaluate(ctx, ast); err != nil {
+		t.Fatalf("Evaluate() unexpected error %v", err)
+	}
+
+	entries := make(map[string]Entry)
+	for _, e := range p.Functions() {
+		entries[e.Name] = e
+	}
+	if fib := entries["fib"]; fib.Count != 15 {
+		t.Errorf("fib called %d times, want 15", fib.Count)
+	}
+	if main := entries["main"]; main.Cumulative != p.total() {
+		t.Errorf("main cumulative time %s, want %s", main.Cumulative, p.total())
+	}
+	if fib := entries["fib"]; fib.Cumulative < fib.Flat || fib.Cumulative >= p.total() {
+		t.Errorf("fib cumulative time %s out of range (flat %s, total %s)", fib.Cumulative, fib.Flat, p.total())
+	}
+	lines := p.Lines()
+	if lines[0].Name != "line 7" || lines[0].Count != 3 {
+		t.Errorf("top line = %+v, want line 7", lines[0])
+	}
+
+	buf := &bytes.Buffer{}
+	if err := p.WriteProfile(buf, "main.tea"); err != nil {
+		t.Fatalf("WriteProfile() unexpected error %v", err)
+	}
+	gz, err := gzip.NewReader(buf)
+	if err != nil {
+		t.Fatalf("WriteProfile() did not write gzip data: %v", err)
+	}
+	data, _ := ioutil.ReadAll(gz)
+	for _, s := range []string{"fib", "main.tea", "nanoseconds"} {
+		if !bytes.Contains(data, []byte(s)) {
+			t.Errorf("profile does not contain %s", s)
+		}
+	}
+}
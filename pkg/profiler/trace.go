@@ -0,0 +1,95 @@
+package profiler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+)
+
+// Logger is a runtime tracer writing each evaluated node and function call with its result.
+type Logger struct {
+	writer io.Writer
+	depth  int
+}
+
+func (l *Logger) log(line, column int, format string, args ...interface{}) {
+	fmt.Fprintf(l.writer, "%d:%d:%s%s\n", line, column, strings.Repeat("  ", l.depth), fmt.Sprintf(format, args...))
+}
+
+func result(value runtime.Value, err error) string {
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return value.String()
+}
+
+// Enter increases the indentation of nested nodes.
+func (l *Logger) Enter(c *runtime.Context, n runtime.Evaluable) {
+	l.depth++
+}
+
+// Leave writes the node with its result. Only the first line of the node source is shown.
+func (l *Logger) Leave(c *runtime.Context, n runtime.Evaluable, value runtime.Value, err error) {
+	l.depth--
+	node, ok := n.(nodes.Node)
+	if !ok {
+		return
+	}
+	line, column := nodes.Position(node)
+	source := strings.SplitN(node.Source(), "\n", 2)
+	if len(source) > 1 {
+		source[0] += " ..."
+	}
+	l.log(line, column, " %s %s => %s", node.Name(), source[0], result(value, err))
+}
+
+// Call writes the function and its arguments.
+func (l *Logger) Call(c *runtime.Context, frame runtime.Frame) {
+	l.log(frame.Line, frame.Column, " call %s%s", frame.Function, frame.Signature)
+	l.depth++
+}
+
+// Return writes the function and its result.
+func (l *Logger) Return(c *runtime.Context, frame runtime.Frame, value runtime.Value, err error) {
+	l.depth--
+	l.log(frame.Line, frame.Column, " return %s => %s", frame.Function, result(value, err))
+}
+
+// NewLogger constructs a new logger writing to the given writer.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{writer: w}
+}
+
+// Tracers notifies all of its tracers in order.
+type Tracers []runtime.Tracer
+
+// Enter notifies all tracers.
+func (t Tracers) Enter(c *runtime.Context, n runtime.Evaluable) {
+	for _, tracer := range t {
+		tracer.Enter(c, n)
+	}
+}
+
+// Leave notifies all tracers.
+func (t Tracers) Leave(c *runtime.Context, n runtime.Evaluable, value runtime.Value, err error) {
+	for _, tracer := range t {
+		tracer.Leave(c, n, value, err)
+	}
+}
+
+// Call notifies all tracers.
+func (t Tracers) Call(c *runtime.Context, frame runtime.Frame) {
+	for _, tracer := range t {
+		tracer.Call(c, frame)
+	}
+}
+
+// Return notifies all tracers.
+func (t Tracers) Return(c *runtime.Context, frame runtime.Frame, value runtime.Value, err error) {
+	for _, tracer := range t {
+		tracer.Return(c, frame, value, err)
+	}
+}
@@ -2,22 +2,34 @@
 package repl
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/optimizer"
 	"github.com/tealang/core/pkg/parser"
 	"github.com/tealang/core/pkg/runtime"
 	"github.com/tealang/core/pkg/runtime/functions"
+	"github.com/tealang/core/pkg/runtime/nodes"
 	"github.com/tealang/core/pkg/runtime/operators"
 	"github.com/tealang/core/pkg/runtime/types"
 )
 
 // Config stores the REPL instance configuration.
 type Config struct {
-	OutputGraph bool
+	OutputGraph  bool
+	OutputSource bool
+	Optimize     bool
+	Hook         runtime.Hook
+	Tracer       runtime.Tracer
+	Limits       runtime.Limits
+	Overflow     runtime.OverflowMode
+	System       runtime.System
+	// Args are the command-line arguments available to the program.
+	Args []string
 }
 
 // Instance is a REPL runtime instance.
@@ -47,16 +59,37 @@ func (r *Instance) Load(file string) error {
 
 // Interpret runs the given input program in the runtime instance.
 func (r *Instance) Interpret(input string) (string, error) {
+	return r.Eval(context.Background(), input)
+}
+
+// Eval runs the given input program in the runtime instance until it completes or the context is done.
+// A cancelled evaluation returns the error of the context and leaves the namespace unchanged.
+func (r *Instance) Eval(ctx context.Context, input string) (string, error) {
 	tokens := lexer.Lex(input)
 	ast, _, err := parser.Parse(tokens)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to interpret")
 	}
+	if r.cfg.Optimize {
+		ast, err = optimizer.Optimize(r.context, ast)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to optimize")
+		}
+	}
 	if r.cfg.OutputGraph {
 		return fmt.Sprintf(graphvizFormat, strings.Join(ast.Graphviz(graphvizItem), "\n")), nil
 	}
-	output, err := ast.Eval(r.context)
+	if r.cfg.OutputSource {
+		return ast.Source(), nil
+	}
+	r.context.Reset()
+	output, err := r.context.Eval(ctx, nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+		return nodes.Evaluate(c, ast)
+	}))
 	if err != nil {
+		if err == ctx.Err() {
+			return "", err
+		}
 		return "", errors.Wrap(err, "failed to interpret")
 	}
 	if output.Type != nil {
@@ -78,6 +111,14 @@ func New(cfg Config) *Instance {
 	operators.Load(ctx)
 	types.Load(ctx)
 	functions.Load(ctx)
+	functions.LoadArgs(ctx, cfg.Args)
+	ctx.Hook = cfg.Hook
+	ctx.Tracer = cfg.Tracer
+	ctx.Limits = cfg.Limits
+	ctx.Overflow = cfg.Overflow
+	if cfg.System != nil {
+		ctx.System = cfg.System
+	}
 
 	return &Instance{
 		Active:  true,
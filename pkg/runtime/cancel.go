@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// snapshot is a copy of the storage of a namespace chain.
+type snapshot map[*Namespace]map[SearchSpace]map[string]SearchItem
+
+func takeSnapshot(ns *Namespace) snapshot {
+	s := make(snapshot)
+	for ; ns != nil; ns = ns.Parent {
+		storage := make(map[SearchSpace]map[string]SearchItem)
+		for space, items := range ns.Storage {
+			storage[space] = make(map[string]SearchItem, len(items))
+			for alias, item := range items {
+				storage[space][alias] = item
+			}
+		}
+		s[ns] = storage
+	}
+	return s
+}
+
+func (s snapshot) restore() {
+	for ns, storage := range s {
+		ns.Storage = storage
+	}
+}
+
+// Err reports whether the evaluation has been cancelled.
+// It is checked on each loop iteration and function call.
+func (c *Context) Err() error {
+	if c.cancel == nil {
+		return nil
+	}
+	return c.cancel.Err()
+}
+
+// GoContext returns a Go context that is done when the evaluation is cancelled or its time limit is exceeded.
+// It is used to stop work outside of the interpreter, e.g. commands run by the program.
+func (c *Context) GoContext() (context.Context, context.CancelFunc) {
+	ctx := c.cancel
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.Limits.Timeout > 0 && !c.deadline.IsZero() {
+		return context.WithDeadline(ctx, c.deadline)
+	}
+	return context.WithCancel(ctx)
+}
+
+// Eval evaluates the node until it completes or the Go context is done.
+// If the evaluation is cancelled, the namespaces visible to the node are restored and the
+// error of the Go context, e.g. context.Canceled or context.DeadlineExceeded, is returned.
+func (c *Context) Eval(ctx context.Context, n Evaluable) (Value, error) {
+	if err := ctx.Err(); err != nil {
+		return Value{}, err
+	}
+	backup := takeSnapshot(c.Namespace)
+	namespace, stack, cancel := c.Namespace, c.Stack, c.cancel
+	c.cancel = ctx
+	defer func() { c.cancel = cancel }()
+
+	value, err := n.Eval(c)
+	if err != nil && ctx.Err() != nil && errors.Cause(err) == ctx.Err() {
+		backup.restore()
+		c.Namespace, c.Stack, c.Behavior = namespace, stack, BehaviorDefault
+		return Value{}, ctx.Err()
+	}
+	return value, err
+}
@@ -0,0 +1,47 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContext_Eval(t *testing.T) {
+	ctx := NewContext()
+	ctx.Namespace.Store(Value{Name: "x", Data: int64(1)})
+	cancelled, cancel := context.WithCancel(context.Background())
+	calls := 0
+	f := NewFunction(ctx.Namespace, NewSignature(Value{}, evaluator(func(c *Context) (Value, error) {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return Value{}, nil
+	}), nil))
+	program := evaluator(func(c *Context) (Value, error) {
+		c.Namespace.Store(Value{Name: "y"})
+		c.Namespace.Storage[SearchIdentifier]["x"] = Value{Name: "x", Data: int64(2)}
+		for {
+			if _, err := f.Eval(c, nil); err != nil {
+				return Value{}, err
+			}
+		}
+	})
+	if _, err := ctx.Eval(cancelled, program); err != context.Canceled {
+		t.Fatalf("Eval() error = %v, want %v", err, context.Canceled)
+	}
+	if calls != 3 {
+		t.Errorf("function called %d times, want 3", calls)
+	}
+	if _, err := ctx.Namespace.Find(SearchIdentifier, "y"); err == nil {
+		t.Error("declaration of cancelled evaluation has not been reverted")
+	}
+	if x, _ := ctx.Namespace.Find(SearchIdentifier, "x"); x.(Value).Data != int64(1) {
+		t.Errorf("x = %v, want 1", x)
+	}
+	if len(ctx.Stack) != 0 || ctx.Err() != nil {
+		t.Error("context has not been reset after cancellation")
+	}
+	if _, err := ctx.Eval(cancelled, program); err != context.Canceled {
+		t.Errorf("Eval() with done context error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -1,6 +1,11 @@
 // Package runtime provides the Tealang runtime functionality including namespaces, context and execution nodes.
 package runtime
 
+import (
+	"context"
+	"time"
+)
+
 // ContextBehavior controls the runtime execution behavior.
 type ContextBehavior int
 
@@ -17,11 +22,60 @@ const (
 	BehaviorFallthrough
 )
 
+func (b ContextBehavior) String() string {
+	switch b {
+	case BehaviorContinue:
+		return "continue"
+	case BehaviorBreak:
+		return "break"
+	case BehaviorReturn:
+		return "return"
+	case BehaviorFallthrough:
+		return "fallthrough"
+	default:
+		return "default"
+	}
+}
+
+// Hook is notified before each statement is evaluated, e.g. to pause the execution in a debugger.
+// Returning an error aborts the execution.
+type Hook interface {
+	Step(c *Context, statement Evaluable) error
+}
+
+// Tracer is notified about the evaluation of nodes and function calls, e.g. to profile the execution.
+// Calls are reported before the frame is pushed on the call stack, returns before it is removed.
+type Tracer interface {
+	Enter(c *Context, n Evaluable)
+	Leave(c *Context, n Evaluable, result Value, err error)
+	Call(c *Context, frame Frame)
+	Return(c *Context, frame Frame, result Value, err error)
+}
+
 // Context is the runtime context the AST is executed in.
 type Context struct {
 	Namespace       *Namespace
 	GlobalNamespace *Namespace
 	Behavior        ContextBehavior
+	Hook            Hook
+	Tracer          Tracer
+	Stack           []Frame
+	Limits          Limits
+	System          System
+	Overflow        OverflowMode
+
+	steps    int
+	deadline time.Time
+	cancel   context.Context
+}
+
+// Frame is an entry of the call stack describing a running function invocation.
+// The position refers to the call site, the caller namespace is the one the call has been made from.
+type Frame struct {
+	Function     string
+	Signature    Signature
+	Caller       *Namespace
+	Line, Column int
 }
 
 // Substitute executes the method in a substituted namespace.
@@ -40,5 +94,6 @@ func NewContext() *Context {
 		Namespace:       ns,
 		GlobalNamespace: ns,
 		Behavior:        BehaviorDefault,
+		System:          Host,
 	}
 }
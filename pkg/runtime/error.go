@@ -0,0 +1,106 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Error is a runtime error carrying the call stack at the moment it occurred.
+// The position refers to the failing statement within the innermost frame.
+type Error struct {
+	Err          error
+	Stack        []Frame
+	Line, Column int
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Cause returns the underlying error.
+func (e *Error) Cause() error {
+	return e.Err
+}
+
+// StackTrace generates a readable stack trace, starting with the innermost frame.
+func (e *Error) StackTrace() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "stack trace:")
+	line, column := e.Line, e.Column
+	for i := len(e.Stack) - 1; i >= 0; i-- {
+		frame := e.Stack[i]
+		fmt.Fprintf(buf, "    %s%s%s\n", frame.Function, frame.Signature, positionString(line, column))
+		line, column = frame.Line, frame.Column
+	}
+	fmt.Fprintf(buf, "    main%s\n", positionString(line, column))
+	return buf.String()
+}
+
+func positionString(line, column int) string {
+	if line == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" at line %d, column %d", line, column)
+}
+
+// NewError attaches the current call stack of the context and the position of the failing statement to the error.
+func NewError(c *Context, err error, line, column int) *Error {
+	stack := make([]Frame, len(c.Stack))
+	copy(stack, c.Stack)
+	return &Error{
+		Err:    err,
+		Stack:  stack,
+		Line:   line,
+		Column: column,
+	}
+}
+
+// TraceOf looks up the runtime error in the chain of wrapped errors.
+// It returns nil if the error does not carry a stack trace.
+func TraceOf(err error) *Error {
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			return e
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return nil
+		}
+		err = cause.Cause()
+	}
+	return nil
+}
+
+// Report formats the error for users, errors carrying a stack trace are reported by the error of the
+// failing statement followed by the trace. Other errors are reported with their full chain of messages.
+func Report(err error) string {
+	trace := TraceOf(err)
+	if trace == nil {
+		return err.Error() + "\n"
+	}
+	return trace.Err.Error() + "\n" + trace.StackTrace()
+}
+
+// ExitError is returned when the program requests to terminate with the status code.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ExitStatus looks up the exit request in the chain of wrapped errors.
+func ExitStatus(err error) (int, bool) {
+	for err != nil {
+		if e, ok := err.(*ExitError); ok {
+			return e.Code, true
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return 0, false
+		}
+		err = cause.Cause()
+	}
+	return 0, false
+}
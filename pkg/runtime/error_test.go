@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestError_StackTrace(t *testing.T) {
+	integer := &Datatype{Name: "int"}
+	ctx := NewContext()
+	ctx.Stack = []Frame{
+		{Function: "f", Signature: NewSignature(Value{}, nil, []Value{{Name: "x", Typeflag: T(integer)}}), Line: 7, Column: 1},
+		{Function: "g", Line: 2, Column: 12},
+	}
+	err := errors.Wrap(NewError(ctx, errors.New("failure"), 5, 5), "failed")
+	trace := TraceOf(err)
+	if trace == nil {
+		t.Fatal("TraceOf() returned nil")
+	}
+	if errors.Cause(err).Error() != "failure" {
+		t.Errorf("Cause() = %v, want failure", errors.Cause(err))
+	}
+	ctx.Stack = ctx.Stack[:0]
+	want := "stack trace:\n    g() at line 5, column 5\n    f(x: int) at line 2, column 12\n    main at line 7, column 1\n"
+	if got := trace.StackTrace(); got != want {
+		t.Errorf("StackTrace() = %q, want %q", got, want)
+	}
+	if TraceOf(errors.New("failure")) != nil {
+		t.Error("TraceOf() of plain error should be nil")
+	}
+}
+
+func TestReport(t *testing.T) {
+	ctx := NewContext()
+	ctx.Stack = []Frame{{Function: "f", Line: 3, Column: 1}}
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Plain", errors.Wrap(errors.New("failure"), "failed"), "failed: failure\n"},
+		{
+			"Trace",
+			errors.Wrap(errors.Wrap(NewError(ctx, errors.Wrap(errors.New("failure"), "statement"), 2, 4), "call"), "program"),
+			"statement: failure\nstack trace:\n    f() at line 2, column 4\n    main at line 3, column 1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Report(tt.err); got != tt.want {
+				t.Errorf("Report() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,14 +13,21 @@ type Evaluable interface {
 }
 
 // Signature stores a function signature consisting of arguments, return value and function body.
+// A variadic signature collects the trailing arguments into the array of its last parameter,
+// each of them has to match the item type of the array.
 type Signature struct {
 	Expected []Value
 	Function Evaluable
 	Returns  Value
+	Variadic bool
 }
 
 // Match checks if the arguments match to the signature.
+// Null arguments have no datatype, they only match parameters of the root datatype.
 func (sign Signature) Match(args []Value) ([]Value, error) {
+	if sign.Variadic && len(sign.Expected) > 0 {
+		return sign.matchVariadic(args)
+	}
 	expected, got := len(sign.Expected), len(args)
 	if expected < got {
 		return nil, errors.Errorf("too many args, expected %d args, got %d", expected, got)
@@ -29,12 +36,9 @@ func (sign Signature) Match(args []Value) ([]Value, error) {
 	matched := make([]Value, expected)
 	for i := range sign.Expected {
 		if got > i {
-			if !args[i].Type.KindOf(sign.Expected[i].Type) {
-				return nil, errors.Errorf("unknown signature, expected type %s for argument %d, got %s", sign.Expected[i].Type, i, args[i].Type)
-			}
-			casted, err := sign.Expected[i].Cast(args[i])
+			casted, err := matchArg(sign.Expected[i], args[i], i)
 			if err != nil {
-				return nil, errors.Wrap(err, "signature not matching")
+				return nil, err
 			}
 			matched[i] = casted
 		} else if sign.Expected[i].Data != nil {
@@ -48,11 +52,56 @@ func (sign Signature) Match(args []Value) ([]Value, error) {
 	return matched, nil
 }
 
+// matchVariadic matches the leading arguments to the fixed parameters and collects the others into an array.
+func (sign Signature) matchVariadic(args []Value) ([]Value, error) {
+	last := len(sign.Expected) - 1
+	if len(args) < last {
+		return nil, errors.Errorf("missing args, expected at least %d, got %d", last, len(args))
+	}
+	rest := sign.Expected[last]
+	if len(rest.Params) != 1 {
+		return nil, errors.Errorf("variadic parameter %s has no item type", rest.Name)
+	}
+	item := Value{Typeflag: rest.Params[0]}
+	items := make([]Value, len(args)-last)
+	for i, arg := range args[last:] {
+		casted, err := matchArg(item, arg, last+i)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = casted
+	}
+	matched, err := Signature{Expected: sign.Expected[:last]}.Match(args[:last])
+	if err != nil {
+		return nil, err
+	}
+	return append(matched, Value{Name: rest.Name, Typeflag: rest.Typeflag, Data: items}), nil
+}
+
+// matchArg checks the type of the argument at the position and casts it to the expected value.
+func matchArg(expected, arg Value, i int) (Value, error) {
+	if arg.Type == nil {
+		if expected.Type.Parent != nil {
+			return Value{}, errors.Errorf("unknown signature, expected type %s for argument %d, got null", expected.Type, i)
+		}
+	} else if !arg.Type.KindOf(expected.Type) {
+		return Value{}, errors.Errorf("unknown signature, expected type %s for argument %d, got %s", expected.Type, i, arg.Type)
+	}
+	casted, err := expected.Cast(arg)
+	if err != nil {
+		return Value{}, errors.Wrap(err, "signature not matching")
+	}
+	return casted, nil
+}
+
 func (sign Signature) String() string {
 	items := make([]string, len(sign.Expected))
 	for i, n := range sign.Expected {
 		items[i] = n.VariableString()
 	}
+	if sign.Variadic && len(items) > 0 {
+		items[len(items)-1] += "..."
+	}
 	if sign.Returns.Type != nil {
 		return fmt.Sprintf("(%s) -> %s", strings.Join(items, ","), sign.Returns.Typeflag)
 	}
@@ -76,20 +125,53 @@ type Function struct {
 
 // Eval executes the function, searching and executing a matching signature.
 func (f Function) Eval(c *Context, args []Value) (Value, error) {
+	return f.Call(c, Frame{}, args)
+}
+
+// Call executes the function like Eval, putting the frame completed by the matched signature on the call stack.
+func (f Function) Call(c *Context, frame Frame, args []Value) (Value, error) {
+	return f.call(c, &frame, args)
+}
+
+// call executes a matching signature, the frame is put on the call stack and reported to the tracer.
+// Without a frame, the signature is executed without entering the call stack.
+func (f Function) call(c *Context, frame *Frame, args []Value) (Value, error) {
 	for _, sign := range f.Signatures {
 		matched, err := sign.Match(args)
 		if err != nil {
 			continue
 		}
+		if err := c.Err(); err != nil {
+			return Value{}, err
+		}
+		if frame != nil {
+			if c.Limits.MaxDepth > 0 && len(c.Stack) >= c.Limits.MaxDepth {
+				return Value{}, &LimitError{Limit: "depth", Value: c.Limits.MaxDepth}
+			}
+			frame.Signature, frame.Caller = sign, c.Namespace
+		}
 		return c.Substitute(func(c *Context) (Value, error) {
+			if frame != nil {
+				if c.Tracer != nil {
+					c.Tracer.Call(c, *frame)
+				}
+				c.Stack = append(c.Stack, *frame)
+				defer func() { c.Stack = c.Stack[:len(c.Stack)-1] }()
+			}
 			c.Namespace = NewNamespace(f.Source)
 			for _, arg := range matched {
 				c.Namespace.Store(arg)
 			}
 			value, err := sign.Function.Eval(c)
+			if frame != nil && c.Tracer != nil {
+				c.Tracer.Return(c, *frame, value, err)
+			}
 			if err != nil {
 				return Value{}, errors.Wrap(err, "failed to evaluate")
 			}
+			if sign.Returns.Type != nil && value.Type == nil {
+				return Value{}, errors.Errorf("expected return type %s, got null", sign.Returns.Type)
+			}
 			if sign.Returns.Type != nil && !value.Type.KindOf(sign.Returns.Type) {
 				return Value{}, errors.Errorf("expected return type %s, got %s", sign.Returns.Type, value.Type)
 			}
@@ -0,0 +1,73 @@
+package functions
+
+import (
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// builtinFunc implements a builtin signature using the arguments in order of the parameters.
+type builtinFunc func(c *runtime.Context, args []runtime.Value) (runtime.Value, error)
+
+// param declares a parameter of a builtin signature.
+func param(name string, datatype *runtime.Datatype) runtime.Value {
+	return runtime.Value{Name: name, Typeflag: runtime.T(datatype)}
+}
+
+// signature constructs a builtin signature, an empty return typeflag allows results of any type.
+func signature(returns runtime.Typeflag, fn builtinFunc, params ...runtime.Value) runtime.Signature {
+	adapter := nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+		args := make([]runtime.Value, len(params))
+		for i, p := range params {
+			item, err := c.Namespace.Find(runtime.SearchIdentifier, p.Name)
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			args[i] = item.(runtime.Value)
+		}
+		return fn(c, args)
+	})
+	return runtime.NewSignature(runtime.Value{Typeflag: returns}, adapter, params)
+}
+
+// variadic marks the signature to collect the trailing arguments into its last parameter, an array.
+func variadic(sign runtime.Signature) runtime.Signature {
+	sign.Variadic = true
+	return sign
+}
+
+// builtin constructs a constant function value.
+func builtin(name string, signatures ...runtime.Signature) runtime.Value {
+	return runtime.Value{
+		Name:     name,
+		Typeflag: runtime.T(types.Function),
+		Data:     runtime.NewFunction(nil, signatures...),
+		Constant: true,
+	}
+}
+
+// failable adapts a builtin whose failures are returned to the program as error values.
+func failable(fn builtinFunc) builtinFunc {
+	return func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		value, err := fn(c, args)
+		if err != nil {
+			return caught(c, err), nil
+		}
+		return value, nil
+	}
+}
+
+// caught converts the failure into an error value carrying the call stack of the failing builtin.
+func caught(c *runtime.Context, err error) runtime.Value {
+	return types.NewError(runtime.NewError(c, err, 0, 0))
+}
+
+// module returns the members of the module stored in the namespace of the context, the module is created if missing.
+func module(c *runtime.Context, name string) *runtime.Namespace {
+	if item, err := c.Namespace.Find(runtime.SearchModule, name); err == nil {
+		return item.(*runtime.Module).Members
+	}
+	m := runtime.NewModule(name)
+	c.Namespace.Store(m)
+	return m.Members
+}
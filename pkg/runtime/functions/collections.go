@@ -0,0 +1,66 @@
+package functions
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// loadCollections loads the accessors of arrays, maps and strings, e.g. the results of json.parse or exec.
+// Elements of arrays are read by index and elements of maps by key, missing keys result in null.
+func loadCollections(c *runtime.Context) {
+	array, object := param("array", types.Array), param("map", types.Map)
+	key := param("key", types.String)
+	integer := func(i int) runtime.Value {
+		return runtime.Value{Typeflag: runtime.T(types.Integer), Data: int64(i)}
+	}
+	c.Namespace.Store(builtin("len",
+		signature(runtime.T(types.Integer), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			return integer(utf8.RuneCountInString(args[0].Data.(string))), nil
+		}, param("text", types.String)),
+		signature(runtime.T(types.Integer), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			items, _ := args[0].Data.([]runtime.Value)
+			return integer(len(items)), nil
+		}, array),
+		signature(runtime.T(types.Integer), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			items, _ := args[0].Data.(map[string]runtime.Value)
+			return integer(len(items)), nil
+		}, object),
+	))
+	c.Namespace.Store(builtin("get",
+		signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			items, _ := args[0].Data.([]runtime.Value)
+			index := args[1].Data.(int64)
+			if index < 0 || index >= int64(len(items)) {
+				return runtime.Value{}, errors.Errorf("index %d out of range, array has %d elements", index, len(items))
+			}
+			return items[index].Rename(""), nil
+		}), array, param("index", types.Integer)),
+		signature(runtime.Typeflag{}, func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			items, _ := args[0].Data.(map[string]runtime.Value)
+			// map elements are named by their key
+			return items[args[1].Data.(string)].Rename(""), nil
+		}, object, key),
+	))
+	c.Namespace.Store(builtin("has", signature(runtime.T(types.Bool), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		items, _ := args[0].Data.(map[string]runtime.Value)
+		_, ok := items[args[1].Data.(string)]
+		return runtime.Value{Typeflag: runtime.T(types.Bool), Data: ok}, nil
+	}, object, key)))
+	c.Namespace.Store(builtin("keys", signature(runtime.T(types.Array, types.String), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		items, _ := args[0].Data.(map[string]runtime.Value)
+		keys := make([]string, 0, len(items))
+		for key := range items {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]runtime.Value, len(keys))
+		for i, key := range keys {
+			values[i] = stringValue(key)
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Array, types.String), Data: values}, nil
+	}, object)))
+}
@@ -0,0 +1,51 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestCollections(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	config, err := parseJSON(`{"name": "tea", "ports": [80, 443], "debug": null}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports := call(t, c, "get", config, stringValue("ports"))
+	index := func(i int64) runtime.Value {
+		return runtime.Value{Typeflag: runtime.T(types.Integer), Data: i}
+	}
+	tests := []struct {
+		name string
+		fn   string
+		args []runtime.Value
+		want interface{}
+	}{
+		{"Map element", "get", []runtime.Value{config, stringValue("name")}, "tea"},
+		{"Missing key", "get", []runtime.Value{config, stringValue("missing")}, nil},
+		{"Array element", "get", []runtime.Value{ports, index(1)}, int64(443)},
+		{"Map length", "len", []runtime.Value{config}, int64(3)},
+		{"Array length", "len", []runtime.Value{ports}, int64(2)},
+		{"String length", "len", []runtime.Value{stringValue("tée")}, int64(3)},
+		{"Present key", "has", []runtime.Value{config, stringValue("debug")}, true},
+		{"Absent key", "has", []runtime.Value{config, stringValue("missing")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := call(t, c, tt.fn, tt.args...)
+			if got.Data != tt.want || got.Name != "" {
+				t.Errorf("%s() = %#v, want %v", tt.fn, got, tt.want)
+			}
+		})
+	}
+	if got := call(t, c, "get", ports, index(2)); got.Type != types.Error {
+		t.Errorf("get() out of range = %v, want error", got)
+	}
+	keys := call(t, c, "keys", config).Data.([]runtime.Value)
+	if len(keys) != 3 || keys[0].Data != "debug" || keys[2].Data != "ports" {
+		t.Errorf("keys() = %v, want sorted keys", keys)
+	}
+}
@@ -0,0 +1,149 @@
+package functions
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/marshal"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// fileInfo is the result of fs.stat.
+type fileInfo struct {
+	Name     string `tea:"name"`
+	Size     int64  `tea:"size"`
+	Dir      bool   `tea:"dir"`
+	Mode     string `tea:"mode"`
+	Modified int64  `tea:"modified"`
+}
+
+// files returns the file system of the context, failing if the sandbox denies file access.
+func files(c *runtime.Context) (runtime.FileSystem, error) {
+	fs := c.System.Files()
+	if fs == nil {
+		return nil, runtime.ErrDenied
+	}
+	return fs, nil
+}
+
+// fileFunc adapts a file operation, its failures are returned as error values.
+func fileFunc(fn func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error)) builtinFunc {
+	return failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		fs, err := files(c)
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		return fn(fs, args)
+	})
+}
+
+// writeFile opens the file using the flags and writes the data to it.
+func writeFile(fs runtime.FileSystem, name, data string, flag int) error {
+	file, err := fs.OpenFile(name, flag, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write([]byte(data)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func loadFiles(c *runtime.Context) {
+	ns := module(c, "fs")
+	name, data := param("name", types.String), param("data", types.String)
+	ns.Store(builtin("read_file", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		file, err := fs.OpenFile(args[0].Data.(string), os.O_RDONLY, 0)
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		defer file.Close()
+		content, err := ioutil.ReadAll(file)
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: string(content)}, nil
+	}), name)))
+	ns.Store(builtin("write_file", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{}, writeFile(fs, args[0].Data.(string), args[1].Data.(string), os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	}), name, data)))
+	ns.Store(builtin("append", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{}, writeFile(fs, args[0].Data.(string), args[1].Data.(string), os.O_WRONLY|os.O_CREATE|os.O_APPEND)
+	}), name, data)))
+	ns.Store(builtin("exists", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		_, err := fs.Stat(args[0].Data.(string))
+		switch {
+		case err == nil:
+			return types.True, nil
+		case os.IsNotExist(err):
+			return types.False, nil
+		}
+		return runtime.Value{}, err
+	}), name)))
+	ns.Store(builtin("list_dir", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		infos, err := fs.ReadDir(args[0].Data.(string))
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		names := make([]string, len(infos))
+		for i, info := range infos {
+			names[i] = info.Name()
+		}
+		return marshal.ToValue(names)
+	}), name)))
+	ns.Store(builtin("mkdir", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{}, fs.MkdirAll(args[0].Data.(string), 0755)
+	}), name)))
+	ns.Store(builtin("remove", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{}, fs.Remove(args[0].Data.(string))
+	}), name)))
+	ns.Store(builtin("stat", signature(runtime.Typeflag{}, fileFunc(func(fs runtime.FileSystem, args []runtime.Value) (runtime.Value, error) {
+		info, err := fs.Stat(args[0].Data.(string))
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		return marshal.ToValue(fileInfo{
+			Name:     info.Name(),
+			Size:     info.Size(),
+			Dir:      info.IsDir(),
+			Mode:     info.Mode().String(),
+			Modified: info.ModTime().Unix(),
+		})
+	}), name)))
+}
+
+// stringFunc adapts a function on strings returning a string.
+func stringFunc(fn func(args []string) string) builtinFunc {
+	return func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		strs := make([]string, len(args))
+		for i, arg := range args {
+			strs[i] = arg.Data.(string)
+		}
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: fn(strs)}, nil
+	}
+}
+
+func loadPaths(c *runtime.Context) {
+	ns := module(c, "path")
+	returns, name := runtime.T(types.String), param("name", types.String)
+	ns.Store(builtin("join", variadic(signature(returns, func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		elems := args[0].Data.([]runtime.Value)
+		strs := make([]string, len(elems))
+		for i, elem := range elems {
+			strs[i] = elem.Data.(string)
+		}
+		return stringValue(path.Join(strs...)), nil
+	}, runtime.Value{Name: "elems", Typeflag: runtime.T(types.Array, types.String)}))))
+	ns.Store(builtin("base", signature(returns, stringFunc(func(args []string) string {
+		return path.Base(args[0])
+	}), name)))
+	ns.Store(builtin("dir", signature(returns, stringFunc(func(args []string) string {
+		return path.Dir(args[0])
+	}), name)))
+	ns.Store(builtin("ext", signature(returns, stringFunc(func(args []string) string {
+		return path.Ext(args[0])
+	}), name)))
+}
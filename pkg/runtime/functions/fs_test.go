@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestFiles(t *testing.T) {
+	root, err := ioutil.TempDir("", "tea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	c := runtime.NewContext()
+	c.System = &runtime.Sandbox{FS: runtime.Dir(root)}
+	Load(c)
+
+	str := func(s string) runtime.Value {
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: s}
+	}
+	tests := []struct {
+		function string
+		args     []runtime.Value
+		want     interface{}
+	}{
+		{"fs.write_file", []runtime.Value{str("a.txt"), str("tea")}, nil},
+		{"fs.append", []runtime.Value{str("/a.txt"), str("pot")}, nil},
+		{"fs.read_file", []runtime.Value{str("../a.txt")}, "teapot"},
+		{"fs.exists", []runtime.Value{str("a.txt")}, true},
+		{"fs.exists", []runtime.Value{str("b.txt")}, false},
+		{"fs.mkdir", []runtime.Value{str("d/e")}, nil},
+		{"fs.list_dir", []runtime.Value{str("")}, []interface{}{"a.txt", "d"}},
+		{"fs.stat", []runtime.Value{str("d")}, true},
+		{"fs.remove", []runtime.Value{str("d/e")}, nil},
+		{"fs.read_file", []runtime.Value{str("b.txt")}, "open b.txt: no such file or directory"},
+		{"path.join", []runtime.Value{str("a"), str("b/.."), str("c.tea")}, "a/c.tea"},
+		{"path.base", []runtime.Value{str("a/c.tea")}, "c.tea"},
+		{"path.dir", []runtime.Value{str("a/c.tea")}, "a"},
+		{"path.ext", []runtime.Value{str("a/c.tea")}, ".tea"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			got := call(t, c, tt.function, tt.args...)
+			switch want := tt.want.(type) {
+			case []interface{}:
+				items := got.Data.([]runtime.Value)
+				if len(items) != len(want) {
+					t.Fatalf("%s() = %v, want %v", tt.function, items, want)
+				}
+				for i := range items {
+					if items[i].Data != want[i] {
+						t.Errorf("%s()[%d] = %v, want %v", tt.function, i, items[i].Data, want[i])
+					}
+				}
+			default:
+				data := got.Data
+				switch got.Type {
+				case types.Error:
+					data = got.Data.(error).Error()
+				case types.Map:
+					data = got.Data.(map[string]runtime.Value)["dir"].Data
+				}
+				if data != want {
+					t.Errorf("%s() = %v, want %v", tt.function, data, want)
+				}
+			}
+		})
+	}
+
+	if _, err := lookup(t, c, "fs.read_file").Eval(c, []runtime.Value{{}}); err == nil {
+		t.Error("fs.read_file(null) expected error")
+	}
+
+	c.System = &runtime.Sandbox{}
+	got := call(t, c, "fs.read_file", str("a.txt"))
+	if got.Type != types.Error || errors.Cause(got.Data.(error)) != runtime.ErrDenied {
+		t.Errorf("fs.read_file() without file system = %v, want denied", got)
+	}
+}
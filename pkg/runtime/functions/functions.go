@@ -6,7 +6,7 @@ import (
 	"github.com/tealang/core/pkg/runtime"
 	"github.com/tealang/core/pkg/runtime/nodes"
 	"github.com/tealang/core/pkg/runtime/types"
-	"os"
+	"io"
 	"strings"
 )
 
@@ -14,6 +14,14 @@ var runtimeFunctions = []func(*runtime.Context){
 	loadTypeof,
 	loadPrint,
 	loadRead,
+	loadIsError,
+	loadCollections,
+	loadFiles,
+	loadPaths,
+	loadJSON,
+	loadRegex,
+	loadTime,
+	loadProcess,
 }
 
 func loadTypeof(c *runtime.Context) {
@@ -49,14 +57,35 @@ func loadTypeof(c *runtime.Context) {
 	c.Namespace.Store(typeof)
 }
 
+// readLine reads up to and including the next newline, unbuffered readers are read byte by byte
+// to leave the remaining input for the next call.
+func readLine(r io.Reader) (string, error) {
+	if buffered, ok := r.(*bufio.Reader); ok {
+		return buffered.ReadString('\n')
+	}
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			line = append(line, b[0])
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+		}
+		if err != nil {
+			return string(line), err
+		}
+	}
+}
+
 func loadRead(c *runtime.Context) {
-	reader := bufio.NewReader(os.Stdin)
 	readAdapter := nodes.NewAdapter(func (c *runtime.Context) (runtime.Value, error) {
 		value, _ := c.Namespace.Find(runtime.SearchIdentifier, "text")
 		if value != nil {
-			fmt.Fprint(os.Stdout, value.(runtime.Value).Data)
+			fmt.Fprint(c.System.Stdout(), value.(runtime.Value).Data)
 		}
-		input, _ := reader.ReadString('\n')
+		input, _ := readLine(c.System.Stdin())
 		return runtime.Value{
 			Typeflag: runtime.T(types.String),
 			Data: strings.TrimSuffix(input, "\n"),
@@ -83,6 +112,23 @@ func loadRead(c *runtime.Context) {
 	c.Namespace.Store(read)
 }
 
+func loadIsError(c *runtime.Context) {
+	c.Namespace.Store(builtin("is_error", signature(runtime.T(types.Bool), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		if types.Underlying(args[0]).Type == types.Error {
+			return types.True, nil
+		}
+		return types.False, nil
+	}, param("value", types.Any))))
+	// stack_trace returns the stack trace of the failed builtin call, null if the error carries none
+	c.Namespace.Store(builtin("stack_trace", signature(runtime.Typeflag{}, func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		err, _ := args[0].Data.(error)
+		if trace := runtime.TraceOf(err); trace != nil {
+			return runtime.Value{Typeflag: runtime.T(types.String), Data: trace.StackTrace()}, nil
+		}
+		return runtime.Value{}, nil
+	}, param("err", types.Error))))
+}
+
 func loadPrint(c *runtime.Context) {
 	printSignature := runtime.Signature{
 		Expected: []runtime.Value{
@@ -93,7 +139,7 @@ func loadPrint(c *runtime.Context) {
 		},
 		Function: nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
 			value, _ := c.Namespace.Find(runtime.SearchIdentifier, "text")
-			fmt.Fprintln(os.Stdout, value.(runtime.Value).Data)
+			fmt.Fprintln(c.System.Stdout(), value.(runtime.Value).Data)
 			return runtime.Value{}, nil
 		}),
 	}
@@ -0,0 +1,66 @@
+package functions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// lookup finds the builtin function, members of modules are given by their qualified name like fs.read_file.
+func lookup(t *testing.T, c *runtime.Context, name string) runtime.Function {
+	var (
+		item runtime.SearchItem
+		err  error
+	)
+	if i := strings.Index(name, "."); i >= 0 {
+		item, err = c.Namespace.FindMember(runtime.SearchIdentifier, name[:i], name[i+1:])
+	} else {
+		item, err = c.Namespace.Find(runtime.SearchIdentifier, name)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return item.(runtime.Value).Data.(runtime.Function)
+}
+
+// call evaluates the builtin function with the arguments.
+func call(t *testing.T, c *runtime.Context, name string, args ...runtime.Value) runtime.Value {
+	value, err := lookup(t, c, name).Eval(c, args)
+	if err != nil {
+		t.Fatalf("%s() error = %v", name, err)
+	}
+	return value
+}
+
+func TestIsError(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	if got := call(t, c, "is_error", types.NewError(runtime.ErrDenied)); got.Data != true {
+		t.Errorf("is_error(error) = %v, want true", got.Data)
+	}
+	if got := call(t, c, "is_error", runtime.Value{}); got.Data != false {
+		t.Errorf("is_error(null) = %v, want false", got.Data)
+	}
+}
+
+func TestReadPrint(t *testing.T) {
+	out := &bytes.Buffer{}
+	c := runtime.NewContext()
+	c.System = &runtime.Sandbox{In: strings.NewReader("first\nsecond\n"), Out: out}
+	Load(c)
+
+	prompt := runtime.Value{Typeflag: runtime.T(types.String), Data: "> "}
+	if got := call(t, c, "read", prompt); got.Data != "first" {
+		t.Errorf("read() = %v, want first", got.Data)
+	}
+	if got := call(t, c, "read"); got.Data != "second" {
+		t.Errorf("read() = %v, want second", got.Data)
+	}
+	call(t, c, "print", runtime.Value{Typeflag: runtime.T(types.Integer), Data: int64(42)})
+	if got, want := out.String(), "> 42\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+package functions
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// decodeJSON converts a decoded JSON value into a Tea value.
+// Objects become maps and arrays become arrays of any, numbers without fraction or exponent become integers.
+func decodeJSON(data interface{}) (runtime.Value, error) {
+	switch data := data.(type) {
+	case nil:
+		return runtime.Value{}, nil
+	case bool:
+		return runtime.Value{Typeflag: runtime.T(types.Bool), Data: data}, nil
+	case string:
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: data}, nil
+	case json.Number:
+		if !strings.ContainsAny(string(data), ".eE") {
+			if i, err := data.Int64(); err == nil {
+				return runtime.Value{Typeflag: runtime.T(types.Integer), Data: i}, nil
+			}
+		}
+		f, err := data.Float64()
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Float), Data: f}, nil
+	case []interface{}:
+		items := make([]runtime.Value, len(data))
+		for i, item := range data {
+			value, err := decodeJSON(item)
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			items[i] = value
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Array, types.Any), Data: items}, nil
+	case map[string]interface{}:
+		items := make(map[string]runtime.Value, len(data))
+		for key, item := range data {
+			value, err := decodeJSON(item)
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			items[key] = value.Rename(key)
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Map, types.Any), Data: items}, nil
+	}
+	return runtime.Value{}, errors.Errorf("unexpected JSON value %v", data)
+}
+
+// parseJSON decodes the text as a single JSON value.
+func parseJSON(text string) (runtime.Value, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return runtime.Value{}, errors.Wrap(err, "invalid JSON")
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return runtime.Value{}, errors.New("invalid JSON: unexpected data after value")
+	}
+	return decodeJSON(data)
+}
+
+// encodeJSON writes the value as JSON, walking arrays and maps by their typeflag.
+// Floats always contain a fraction or exponent, so they are parsed as floats again.
+func encodeJSON(buf *bytes.Buffer, value runtime.Value) error {
+	value = types.Underlying(value)
+	if value.Type == types.Any && value.Data == nil {
+		// null cast to any
+		value = runtime.Value{}
+	}
+	switch value.Type {
+	case nil:
+		buf.WriteString("null")
+	case types.Bool:
+		buf.WriteString(strconv.FormatBool(value.Data.(bool)))
+	case types.Integer:
+		buf.WriteString(strconv.FormatInt(value.Data.(int64), 10))
+	case types.Float:
+		f := value.Data.(float64)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return errors.Errorf("can not encode %v as JSON", f)
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		buf.WriteString(s)
+	case types.BigInt, types.Decimal:
+		buf.WriteString(value.String())
+	case types.String:
+		data, err := json.Marshal(value.Data.(string))
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	case types.Array:
+		items, _ := value.Data.([]runtime.Value)
+		if items == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeJSON(buf, item); err != nil {
+				return errors.Wrapf(err, "index %d", i)
+			}
+		}
+		buf.WriteByte(']')
+	case types.Map:
+		items, _ := value.Data.(map[string]runtime.Value)
+		if items == nil {
+			buf.WriteString("null")
+			return nil
+		}
+		keys := make([]string, 0, len(items))
+		for key := range items {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			data, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(data)
+			buf.WriteByte(':')
+			if err := encodeJSON(buf, items[key]); err != nil {
+				return errors.Wrapf(err, "key %s", key)
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return errors.Errorf("can not encode value of type %s as JSON", value.Typeflag)
+	}
+	return nil
+}
+
+// stringifyJSON encodes the value, indenting nested elements by the number of spaces if positive.
+func stringifyJSON(value runtime.Value, indent int64) (runtime.Value, error) {
+	buf := &bytes.Buffer{}
+	if err := encodeJSON(buf, value); err != nil {
+		return runtime.Value{}, err
+	}
+	if indent > 0 {
+		indented := &bytes.Buffer{}
+		if err := json.Indent(indented, buf.Bytes(), "", strings.Repeat(" ", int(indent))); err != nil {
+			return runtime.Value{}, err
+		}
+		buf = indented
+	}
+	return runtime.Value{Typeflag: runtime.T(types.String), Data: buf.String()}, nil
+}
+
+func loadJSON(c *runtime.Context) {
+	ns := module(c, "json")
+	value := param("value", types.Any)
+	ns.Store(builtin("parse", signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return parseJSON(args[0].Data.(string))
+	}), param("text", types.String))))
+	ns.Store(builtin("stringify",
+		signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			return stringifyJSON(args[0], 0)
+		}), value),
+		signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			return stringifyJSON(args[0], args[1].Data.(int64))
+		}), value, param("indent", types.Integer)),
+	))
+}
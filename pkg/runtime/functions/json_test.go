@@ -0,0 +1,85 @@
+package functions
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestJSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"Null", `null`, `null`},
+		{"Bool", `true`, `true`},
+		{"Integer", `-42`, `-42`},
+		{"Float", `1.0`, `1.0`},
+		{"Exponent", `1e3`, `1000.0`},
+		{"String", `"tea \"pot\"\n"`, `"tea \"pot\"\n"`},
+		{"Array", `[1, "two", [3.5], null]`, `[1,"two",[3.5],null]`},
+		{"Map", `{"b": {"c": false}, "a": []}`, `{"a":[],"b":{"c":false}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := parseJSON(tt.input)
+			if err != nil {
+				t.Fatalf("parseJSON() error = %v", err)
+			}
+			got, err := stringifyJSON(value, 0)
+			if err != nil {
+				t.Fatalf("stringifyJSON() error = %v", err)
+			}
+			if got.Data != tt.want {
+				t.Errorf("stringifyJSON() = %s, want %s", got.Data, tt.want)
+			}
+			again, err := parseJSON(got.Data.(string))
+			if err != nil {
+				t.Fatalf("parseJSON() of output error = %v", err)
+			}
+			if !reflect.DeepEqual(again.Typeflag, value.Typeflag) {
+				t.Errorf("round trip typeflag = %s, want %s", again.Typeflag, value.Typeflag)
+			}
+		})
+	}
+}
+
+func TestJSON_Builtins(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	text := runtime.Value{Typeflag: runtime.T(types.String), Data: `{"list": [1, 2]}`}
+	indent := runtime.Value{Typeflag: runtime.T(types.Integer), Data: int64(2)}
+
+	parsed := call(t, c, "json.parse", text)
+	if parsed.Type != types.Map {
+		t.Fatalf("json.parse() = %s, want map", parsed.Typeflag)
+	}
+	got := call(t, c, "json.stringify", parsed, indent)
+	if want := "{\n  \"list\": [\n    1,\n    2\n  ]\n}"; got.Data != want {
+		t.Errorf("json.stringify() = %q, want %q", got.Data, want)
+	}
+	if got := call(t, c, "json.stringify", runtime.Value{}); got.Data != "null" {
+		t.Errorf("json.stringify(null) = %v, want null", got.Data)
+	}
+
+	errs := []struct {
+		name     string
+		function string
+		arg      runtime.Value
+		want     string
+	}{
+		{"Invalid", "json.parse", runtime.Value{Typeflag: runtime.T(types.String), Data: `{"a": }`}, "invalid JSON"},
+		{"Trailing", "json.parse", runtime.Value{Typeflag: runtime.T(types.String), Data: `1 2`}, "unexpected data"},
+		{"Function", "json.stringify", c.GlobalNamespace.Storage[runtime.SearchIdentifier]["print"].(runtime.Value), "can not encode value of type func"},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			got := call(t, c, tt.function, tt.arg)
+			if got.Type != types.Error || !strings.Contains(got.Data.(error).Error(), tt.want) {
+				t.Errorf("%s() = %v, want error containing %q", tt.function, got, tt.want)
+			}
+		})
+	}
+}
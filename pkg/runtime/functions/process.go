@@ -0,0 +1,88 @@
+package functions
+
+import (
+	"bytes"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func stringValue(s string) runtime.Value {
+	return runtime.Value{Typeflag: runtime.T(types.String), Data: s}
+}
+
+// LoadArgs stores the command-line arguments of the program as args array.
+func LoadArgs(c *runtime.Context, args []string) {
+	items := make([]runtime.Value, len(args))
+	for i, arg := range args {
+		items[i] = stringValue(arg)
+	}
+	c.Namespace.Store(runtime.Value{
+		Name:     "args",
+		Typeflag: runtime.T(types.Array, types.String),
+		Data:     items,
+		Constant: true,
+	})
+}
+
+func loadProcess(c *runtime.Context) {
+	exit := func(code int64) (runtime.Value, error) {
+		return runtime.Value{}, &runtime.ExitError{Code: int(code)}
+	}
+	c.Namespace.Store(builtin("exit",
+		signature(runtime.Typeflag{}, func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			return exit(0)
+		}),
+		signature(runtime.Typeflag{}, func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+			return exit(args[0].Data.(int64))
+		}, param("code", types.Integer)),
+	))
+
+	env, key := module(c, "env"), param("key", types.String)
+	env.Store(builtin("get", signature(runtime.Typeflag{}, func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		value, ok := c.System.Getenv(args[0].Data.(string))
+		if !ok {
+			return runtime.Value{}, nil
+		}
+		return stringValue(value), nil
+	}, key)))
+	env.Store(builtin("set", signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{}, c.System.Setenv(args[0].Data.(string), args[1].Data.(string))
+	}), key, param("value", types.String))))
+
+	// run executes the command, its arguments are given as an array or separately collected into one.
+	// The command is killed if the evaluation is cancelled or times out, which stops the program.
+	run := func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		items := args[1].Data.([]runtime.Value)
+		arguments := make([]string, len(items))
+		for i, item := range items {
+			arguments[i], _ = item.Data.(string)
+		}
+		ctx, cancel := c.GoContext()
+		defer cancel()
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		status, err := c.System.Exec(ctx, args[0].Data.(string), arguments, stdout, stderr)
+		if ctx.Err() != nil {
+			if err := c.Err(); err != nil {
+				return runtime.Value{}, err
+			}
+			return runtime.Value{}, &runtime.LimitError{Limit: "time", Value: c.Limits.Timeout}
+		}
+		if err != nil {
+			return caught(c, err), nil
+		}
+		return runtime.Value{
+			Typeflag: runtime.T(types.Map, types.Any),
+			Data: map[string]runtime.Value{
+				"status": {Name: "status", Typeflag: runtime.T(types.Integer), Data: int64(status)},
+				"stdout": stringValue(stdout.String()).Rename("stdout"),
+				"stderr": stringValue(stderr.String()).Rename("stderr"),
+			},
+		}, nil
+	}
+	name, arguments := param("name", types.String), runtime.Value{Name: "args", Typeflag: runtime.T(types.Array, types.String)}
+	c.Namespace.Store(builtin("exec",
+		signature(runtime.Typeflag{}, run, name, arguments),
+		variadic(signature(runtime.Typeflag{}, run, name, arguments)),
+	))
+}
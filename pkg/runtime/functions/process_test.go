@@ -0,0 +1,138 @@
+package functions
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestProcess(t *testing.T) {
+	c := runtime.NewContext()
+	sandbox := &runtime.Sandbox{Env: map[string]string{"HOME": "/tea"}}
+	c.System = sandbox
+	Load(c)
+	LoadArgs(c, []string{"a", "b"})
+	item, err := c.Namespace.Find(runtime.SearchIdentifier, "args")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args := item.(runtime.Value).Data.([]runtime.Value); len(args) != 2 || args[1].Data != "b" {
+		t.Errorf("args = %v, want [a b]", args)
+	}
+	if got := call(t, c, "env.get", stringValue("HOME")); got.Data != "/tea" {
+		t.Errorf("env.get(HOME) = %v, want /tea", got)
+	}
+	if got := call(t, c, "env.get", stringValue("PATH")); got.Type != nil {
+		t.Errorf("env.get(PATH) = %v, want null", got)
+	}
+	call(t, c, "env.set", stringValue("PATH"), stringValue("/bin"))
+	if sandbox.Env["PATH"] != "/bin" {
+		t.Errorf("env.set() did not change the environment")
+	}
+	sandbox.ReadOnlyEnv = true
+	if got := call(t, c, "env.set", stringValue("PATH"), stringValue("/usr/bin")); got.Type != types.Error {
+		t.Errorf("env.set() in read-only environment = %v, want error", got)
+	}
+	if got := call(t, c, "exec", stringValue("echo"), stringValue("tea")); got.Type != types.Error {
+		t.Errorf("exec() in sandbox = %v, want error", got)
+	}
+	item, _ = c.Namespace.Find(runtime.SearchIdentifier, "exit")
+	code := runtime.Value{Typeflag: runtime.T(types.Integer), Data: int64(3)}
+	_, err = item.(runtime.Value).Data.(runtime.Function).Eval(c, []runtime.Value{code})
+	if status, ok := runtime.ExitStatus(errors.Wrap(err, "exiting")); !ok || status != 3 {
+		t.Errorf("exit(3) error = %v, want exit status 3", err)
+	}
+}
+
+// evaluable calls the function when evaluated.
+type evaluable func(c *runtime.Context) (runtime.Value, error)
+
+func (e evaluable) Eval(c *runtime.Context) (runtime.Value, error) {
+	return e(c)
+}
+
+func TestProcess_Kill(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep is not available")
+	}
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		cancel  time.Duration
+		want    func(error) bool
+	}{
+		{"Cancel", 0, 50 * time.Millisecond, func(err error) bool { return err == context.Canceled }},
+		{"Timeout", 50 * time.Millisecond, 0, func(err error) bool {
+			_, ok := errors.Cause(err).(*runtime.LimitError)
+			return ok
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := runtime.NewContext()
+			c.System = &runtime.Sandbox{AllowExec: true}
+			c.Limits.Timeout = tt.timeout
+			Load(c)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel > 0 {
+				time.AfterFunc(tt.cancel, cancel)
+			}
+			start := time.Now()
+			_, err := c.Eval(ctx, evaluable(func(c *runtime.Context) (runtime.Value, error) {
+				if err := c.Charge(); err != nil {
+					return runtime.Value{}, err
+				}
+				return lookup(t, c, "exec").Eval(c, []runtime.Value{stringValue("sleep"), stringValue("10")})
+			}))
+			if !tt.want(err) {
+				t.Errorf("exec() error = %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("exec() took %s, want the command killed", elapsed)
+			}
+		})
+	}
+}
+
+func TestProcess_Exec(t *testing.T) {
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo is not available")
+	}
+	array := func(items ...string) runtime.Value {
+		values := make([]runtime.Value, len(items))
+		for i, item := range items {
+			values[i] = stringValue(item)
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Array, types.String), Data: values}
+	}
+	tests := []struct {
+		name string
+		args []runtime.Value
+		want string
+	}{
+		{"No arguments", nil, "\n"},
+		{"Separate arguments", []runtime.Value{stringValue("a"), stringValue("b"), stringValue("c")}, "a b c\n"},
+		{"Array", []runtime.Value{array("a", "b")}, "a b\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := runtime.NewContext()
+			c.System = &runtime.Sandbox{AllowExec: true}
+			Load(c)
+			got := call(t, c, "exec", append([]runtime.Value{stringValue("echo")}, tt.args...)...)
+			result, ok := got.Data.(map[string]runtime.Value)
+			if !ok {
+				t.Fatalf("exec() = %v, want result map", got)
+			}
+			if stdout := result["stdout"].Data; stdout != tt.want {
+				t.Errorf("exec() stdout = %q, want %q", stdout, tt.want)
+			}
+		})
+	}
+}
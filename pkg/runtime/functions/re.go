@@ -0,0 +1,114 @@
+package functions
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// regexFunc implements a regex operation with the pattern and the string arguments following it.
+type regexFunc func(re *regexp.Regexp, args []string) runtime.Value
+
+// regexSignatures constructs signatures accepting compiled patterns or pattern strings as first argument.
+// Pattern strings are compiled on each call, invalid patterns result in error values.
+func regexSignatures(fn regexFunc, params ...string) []runtime.Signature {
+	texts := func(args []runtime.Value) []string {
+		strs := make([]string, len(args))
+		for i, arg := range args {
+			strs[i] = arg.Data.(string)
+		}
+		return strs
+	}
+	declare := func(pattern *runtime.Datatype) []runtime.Value {
+		declared := []runtime.Value{param("pattern", pattern)}
+		for _, name := range params {
+			declared = append(declared, param(name, types.String))
+		}
+		return declared
+	}
+	compiled := failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		re, ok := args[0].Data.(*regexp.Regexp)
+		if !ok {
+			return runtime.Value{}, errors.New("missing pattern")
+		}
+		return fn(re, texts(args[1:])), nil
+	})
+	uncompiled := failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		re, err := regexp.Compile(args[0].Data.(string))
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		return fn(re, texts(args[1:])), nil
+	})
+	return []runtime.Signature{
+		signature(runtime.Typeflag{}, compiled, declare(types.Regex)...),
+		signature(runtime.Typeflag{}, uncompiled, declare(types.String)...),
+	}
+}
+
+// submatches converts the index pairs of the match and its capture groups into strings,
+// groups which did not participate in the match are null.
+func submatches(text string, loc []int) runtime.Value {
+	items := make([]runtime.Value, len(loc)/2)
+	for i := range items {
+		if start, end := loc[2*i], loc[2*i+1]; start >= 0 {
+			items[i] = runtime.Value{Typeflag: runtime.T(types.String), Data: text[start:end]}
+		}
+	}
+	return runtime.Value{Typeflag: runtime.T(types.Array, types.String), Data: items}
+}
+
+func loadRegex(c *runtime.Context) {
+	ns := module(c, "re")
+	ns.Store(builtin("compile", signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return types.Regex.Cast(args[0], nil)
+	}), param("pattern", types.String))))
+	ns.Store(builtin("match", regexSignatures(func(re *regexp.Regexp, args []string) runtime.Value {
+		if re.MatchString(args[0]) {
+			return types.True
+		}
+		return types.False
+	}, "text")...))
+	ns.Store(builtin("find", regexSignatures(func(re *regexp.Regexp, args []string) runtime.Value {
+		loc := re.FindStringSubmatchIndex(args[0])
+		if loc == nil {
+			return runtime.Value{}
+		}
+		return submatches(args[0], loc)
+	}, "text")...))
+	ns.Store(builtin("find_all", regexSignatures(func(re *regexp.Regexp, args []string) runtime.Value {
+		locs := re.FindAllStringSubmatchIndex(args[0], -1)
+		matches := make([]runtime.Value, len(locs))
+		for i, loc := range locs {
+			matches[i] = submatches(args[0], loc)
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Array, types.Array, types.String), Data: matches}
+	}, "text")...))
+	ns.Store(builtin("find_named", regexSignatures(func(re *regexp.Regexp, args []string) runtime.Value {
+		loc := re.FindStringSubmatchIndex(args[0])
+		if loc == nil {
+			return runtime.Value{}
+		}
+		groups := submatches(args[0], loc).Data.([]runtime.Value)
+		named := make(map[string]runtime.Value)
+		for i, name := range re.SubexpNames() {
+			if name != "" {
+				named[name] = groups[i].Rename(name)
+			}
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Map, types.String), Data: named}
+	}, "text")...))
+	ns.Store(builtin("replace", regexSignatures(func(re *regexp.Regexp, args []string) runtime.Value {
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: re.ReplaceAllString(args[0], args[1])}
+	}, "text", "replacement")...))
+	ns.Store(builtin("split", regexSignatures(func(re *regexp.Regexp, args []string) runtime.Value {
+		parts := re.Split(args[0], -1)
+		items := make([]runtime.Value, len(parts))
+		for i, part := range parts {
+			items[i] = runtime.Value{Typeflag: runtime.T(types.String), Data: part}
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Array, types.String), Data: items}
+	}, "text")...))
+}
@@ -0,0 +1,52 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestRegex(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	str := func(s string) runtime.Value {
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: s}
+	}
+	compiled := call(t, c, "re.compile", str(`(?P<key>\w+)=(?P<value>\d+)?`))
+	if compiled.Type != types.Regex {
+		t.Fatalf("re.compile() = %v, want regex", compiled)
+	}
+	// format matched values as their JSON representation for comparison
+	format := func(v runtime.Value) string {
+		value, err := stringifyJSON(v, 0)
+		if err != nil {
+			return "error: " + err.Error()
+		}
+		return value.Data.(string)
+	}
+	tests := []struct {
+		function string
+		args     []runtime.Value
+		want     string
+	}{
+		{"re.match", []runtime.Value{compiled, str("a=1")}, `true`},
+		{"re.match", []runtime.Value{str(`^\d+$`), str("a=1")}, `false`},
+		{"re.find", []runtime.Value{compiled, str("x a=1 b=")}, `["a=1","a","1"]`},
+		{"re.find", []runtime.Value{compiled, str("none")}, `null`},
+		{"re.find_all", []runtime.Value{compiled, str("a=1 b=")}, `[["a=1","a","1"],["b=","b",null]]`},
+		{"re.find_named", []runtime.Value{compiled, str("a=1")}, `{"key":"a","value":"1"}`},
+		{"re.replace", []runtime.Value{compiled, str("a=1 b=2"), str("$value:$key")}, `"1:a 2:b"`},
+		{"re.split", []runtime.Value{str(`\s*,\s*`), str("a , b,c")}, `["a","b","c"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			if got := format(call(t, c, tt.function, tt.args...)); got != tt.want {
+				t.Errorf("%s() = %s, want %s", tt.function, got, tt.want)
+			}
+		})
+	}
+	if got := call(t, c, "re.match", str("("), str("")); got.Type != types.Error {
+		t.Errorf("re.match() with invalid pattern = %v, want error", got)
+	}
+}
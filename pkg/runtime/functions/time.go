@@ -0,0 +1,74 @@
+package functions
+
+import (
+	"time"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// timeLayouts are the named layouts accepted by parse and format,
+// other layouts are written using the reference time of the Go time package.
+var timeLayouts = map[string]string{
+	"rfc3339":  time.RFC3339,
+	"date":     "2006-01-02",
+	"datetime": "2006-01-02 15:04:05",
+	"kitchen":  time.Kitchen,
+}
+
+// layoutOf resolves named layouts.
+func layoutOf(layout runtime.Value) string {
+	if named, ok := timeLayouts[layout.Data.(string)]; ok {
+		return named
+	}
+	return layout.Data.(string)
+}
+
+func timeValue(t time.Time) runtime.Value {
+	return runtime.Value{Typeflag: runtime.T(types.Time), Data: t}
+}
+
+func durationValue(d time.Duration) runtime.Value {
+	return runtime.Value{Typeflag: runtime.T(types.Duration), Data: d}
+}
+
+func loadTime(c *runtime.Context) {
+	times, durations := module(c, "time"), module(c, "duration")
+	t, layout, text := param("t", types.Time), param("layout", types.String), param("text", types.String)
+	times.Store(builtin("now", signature(runtime.T(types.Time), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return timeValue(c.System.Now()), nil
+	})))
+	times.Store(builtin("since", signature(runtime.T(types.Duration), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return durationValue(c.System.Now().Sub(args[0].Data.(time.Time))), nil
+	}, t)))
+	times.Store(builtin("parse", signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		parsed, err := time.Parse(layoutOf(args[0]), args[1].Data.(string))
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		return timeValue(parsed), nil
+	}), layout, text)))
+	times.Store(builtin("format", signature(runtime.T(types.String), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{
+			Typeflag: runtime.T(types.String),
+			Data:     args[0].Data.(time.Time).Format(layoutOf(args[1])),
+		}, nil
+	}, t, layout)))
+	times.Store(builtin("unix", signature(runtime.T(types.Integer), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{Typeflag: runtime.T(types.Integer), Data: args[0].Data.(time.Time).Unix()}, nil
+	}, t)))
+	times.Store(builtin("from_unix", signature(runtime.T(types.Time), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return timeValue(time.Unix(args[0].Data.(int64), 0).UTC()), nil
+	}, param("seconds", types.Integer))))
+
+	d := param("d", types.Duration)
+	durations.Store(builtin("parse", signature(runtime.Typeflag{}, failable(func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return types.Duration.Cast(args[0], nil)
+	}), text)))
+	durations.Store(builtin("seconds", signature(runtime.T(types.Float), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{Typeflag: runtime.T(types.Float), Data: args[0].Data.(time.Duration).Seconds()}, nil
+	}, d)))
+	durations.Store(builtin("milliseconds", signature(runtime.T(types.Integer), func(c *runtime.Context, args []runtime.Value) (runtime.Value, error) {
+		return runtime.Value{Typeflag: runtime.T(types.Integer), Data: int64(args[0].Data.(time.Duration) / time.Millisecond)}, nil
+	}, d)))
+}
@@ -0,0 +1,46 @@
+package functions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestTime(t *testing.T) {
+	now := time.Date(2018, 3, 4, 12, 30, 0, 0, time.UTC)
+	c := runtime.NewContext()
+	c.System = &runtime.Sandbox{Clock: func() time.Time { return now }}
+	Load(c)
+	str := func(s string) runtime.Value {
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: s}
+	}
+	if got := call(t, c, "time.now"); got.Data != now {
+		t.Errorf("time.now() = %v, want %v", got, now)
+	}
+	parsed := call(t, c, "time.parse", str("date"), str("2018-03-04"))
+	if got := call(t, c, "time.since", parsed); got.Data != 12*time.Hour+30*time.Minute {
+		t.Errorf("time.since() = %v, want 12h30m", got)
+	}
+	if got := call(t, c, "time.format", parsed, str("Jan 2")); got.Data != "Mar 4" {
+		t.Errorf("time.format() = %v, want Mar 4", got)
+	}
+	unix := call(t, c, "time.unix", parsed)
+	if got := call(t, c, "time.from_unix", unix); got.Data != parsed.Data {
+		t.Errorf("time.from_unix(%v) = %v, want %v", unix, got, parsed)
+	}
+	d := call(t, c, "duration.parse", str("1m30s"))
+	if got := call(t, c, "duration.seconds", d); got.Data != 90.0 {
+		t.Errorf("duration.seconds() = %v, want 90", got)
+	}
+	if got := call(t, c, "duration.milliseconds", d); got.Data != int64(90000) {
+		t.Errorf("duration.milliseconds() = %v, want 90000", got)
+	}
+	if got := call(t, c, "time.parse", str("date"), str("today")); got.Type != types.Error {
+		t.Errorf("time.parse() with invalid text = %v, want error", got)
+	}
+	if got := call(t, c, "duration.parse", str("1 hour")); got.Type != types.Error {
+		t.Errorf("duration.parse() with invalid text = %v, want error", got)
+	}
+}
@@ -0,0 +1,87 @@
+package runtime
+
+import "testing"
+
+func TestSignature_Match(t *testing.T) {
+	root := &Datatype{Name: "any"}
+	root.Cast = func(v Value, f []Typeflag) (Value, error) {
+		return Value{Typeflag: T(root), Data: v.Data}, nil
+	}
+	integer := &Datatype{Name: "int", Parent: root, Cast: func(v Value, f []Typeflag) (Value, error) {
+		return v, nil
+	}}
+	tests := []struct {
+		name     string
+		expected *Datatype
+		arg      Value
+		wantErr  bool
+	}{
+		{"Same type", integer, Value{Typeflag: T(integer), Data: int64(1)}, false},
+		{"Parent type", root, Value{Typeflag: T(integer), Data: int64(1)}, false},
+		{"Child type", integer, Value{Typeflag: T(root)}, true},
+		{"Null as any", root, Value{}, false},
+		{"Null as int", integer, Value{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sign := NewSignature(Value{}, nil, []Value{{Name: "x", Typeflag: T(tt.expected)}})
+			matched, err := sign.Match([]Value{tt.arg})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Match() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (matched[0].Name != "x" || matched[0].Type != tt.expected) {
+				t.Errorf("Match() = %v, want argument x of type %s", matched[0], tt.expected)
+			}
+		})
+	}
+}
+
+func TestSignature_Match_Variadic(t *testing.T) {
+	root := &Datatype{Name: "any"}
+	cast := func(v Value, f []Typeflag) (Value, error) {
+		return v, nil
+	}
+	integer := &Datatype{Name: "int", Parent: root, Cast: cast}
+	str := &Datatype{Name: "string", Parent: root, Cast: cast}
+	array := &Datatype{Name: "array", Parent: root, Cast: cast}
+	sign := Signature{
+		Expected: []Value{{Name: "sep", Typeflag: T(str)}, {Name: "elems", Typeflag: T(array, integer)}},
+		Variadic: true,
+	}
+	number := func(i int64) Value { return Value{Typeflag: T(integer), Data: i} }
+	sep := Value{Typeflag: T(str), Data: ","}
+	tests := []struct {
+		name    string
+		args    []Value
+		want    int
+		wantErr bool
+	}{
+		{"No items", []Value{sep}, 0, false},
+		{"One item", []Value{sep, number(1)}, 1, false},
+		{"Many items", []Value{sep, number(1), number(2), number(3)}, 3, false},
+		{"Missing fixed", nil, 0, true},
+		{"Wrong fixed", []Value{number(1), number(2)}, 0, true},
+		{"Wrong item", []Value{sep, number(1), sep}, 0, true},
+		{"Null item", []Value{sep, {}}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := sign.Match(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Match() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(matched) != 2 || matched[1].Name != "elems" || matched[1].Type != array {
+				t.Fatalf("Match() = %v, want sep and an array of elems", matched)
+			}
+			if items := matched[1].Data.([]Value); len(items) != tt.want {
+				t.Errorf("Match() collected %d items, want %d", len(items), tt.want)
+			}
+		})
+	}
+	if got, want := sign.String(), "(sep: string,elems: array<int>...)"; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package runtime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Limits restricts the resources available to a program, zero values disable the limit.
+type Limits struct {
+	// MaxSteps is the number of nodes that may be evaluated.
+	MaxSteps int
+	// MaxDepth is the number of nested function calls.
+	MaxDepth int
+	// MaxSize is the size of strings, arrays and maps that may be created,
+	// including the contents of nested arrays and maps.
+	MaxSize int
+	// Timeout is the wall-clock time the evaluation may take.
+	Timeout time.Duration
+}
+
+// LimitError is returned when a program exceeds one of its resource limits.
+type LimitError struct {
+	Limit string
+	Value interface{}
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s limit of %v exceeded", e.Limit, e.Value)
+}
+
+// Reset restarts the step count and timeout of the context.
+func (c *Context) Reset() {
+	c.steps = 0
+	c.deadline = time.Time{}
+}
+
+// Charge counts an evaluation step, it fails if the step or time limit is exceeded.
+// The timeout starts with the first step after a reset.
+func (c *Context) Charge() error {
+	c.steps++
+	if c.Limits.MaxSteps > 0 && c.steps > c.Limits.MaxSteps {
+		return &LimitError{Limit: "step", Value: c.Limits.MaxSteps}
+	}
+	if c.Limits.Timeout > 0 {
+		now := time.Now()
+		if c.deadline.IsZero() {
+			c.deadline = now.Add(c.Limits.Timeout)
+		} else if now.After(c.deadline) {
+			return &LimitError{Limit: "time", Value: c.Limits.Timeout}
+		}
+	}
+	return nil
+}
+
+// Allocate checks the size of the value, it fails if the string, array or map is larger than allowed.
+// The size of arrays and maps includes the sizes of their elements and keys.
+func (c *Context) Allocate(v Value) error {
+	if c.Limits.MaxSize <= 0 {
+		return nil
+	}
+	if sizeOf(v.Data, c.Limits.MaxSize) > c.Limits.MaxSize {
+		return &LimitError{Limit: "size", Value: c.Limits.MaxSize}
+	}
+	return nil
+}
+
+// sizeOf counts the length of the data and its nested strings, arrays and maps.
+// Counting stops once the size exceeds max.
+func sizeOf(data interface{}, max int) int {
+	switch data := data.(type) {
+	case string:
+		return len(data)
+	case []Value:
+		size := len(data)
+		for _, item := range data {
+			if size > max {
+				break
+			}
+			size += sizeOf(item.Data, max)
+		}
+		return size
+	case map[string]Value:
+		size := len(data)
+		for key, item := range data {
+			if size > max {
+				break
+			}
+			size += len(key) + sizeOf(item.Data, max)
+		}
+		return size
+	}
+	return 0
+}
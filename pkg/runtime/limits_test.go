@@ -0,0 +1,118 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestContext_Charge(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		steps  int
+		sleep  time.Duration
+		limit  string
+	}{
+		{"Unlimited", Limits{}, 100, 0, ""},
+		{"StepsWithinLimit", Limits{MaxSteps: 100}, 100, 0, ""},
+		{"StepsExceeded", Limits{MaxSteps: 100}, 101, 0, "step"},
+		{"TimeExceeded", Limits{Timeout: time.Millisecond}, 2, 2 * time.Millisecond, "time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext()
+			ctx.Limits = tt.limits
+			var err error
+			for i := 0; i < tt.steps && err == nil; i++ {
+				err = ctx.Charge()
+				time.Sleep(tt.sleep)
+			}
+			checkLimit(t, err, tt.limit)
+			ctx.Reset()
+			if tt.limits.Timeout == 0 {
+				if err := ctx.Charge(); err != nil {
+					t.Errorf("Charge() after Reset() unexpected error %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestContext_Allocate(t *testing.T) {
+	ctx := NewContext()
+	ctx.Limits.MaxSize = 3
+	checkLimit(t, ctx.Allocate(Value{Data: "abc"}), "")
+	checkLimit(t, ctx.Allocate(Value{Data: "abcd"}), "size")
+	checkLimit(t, ctx.Allocate(Value{Data: make([]Value, 4)}), "size")
+	checkLimit(t, ctx.Allocate(Value{Data: int64(12345)}), "")
+	checkLimit(t, ctx.Allocate(Value{Data: []Value{{Data: "a"}, {Data: "b"}}}), "size")
+	checkLimit(t, ctx.Allocate(Value{Data: []Value{{Data: []Value{{}, {}, {}}}}}), "size")
+	checkLimit(t, ctx.Allocate(Value{Data: map[string]Value{"ab": {Data: "c"}}}), "size")
+	checkLimit(t, ctx.Allocate(Value{Data: []Value{{Data: "a"}, {}}}), "")
+}
+
+func TestFunction_CallDepth(t *testing.T) {
+	ctx := NewContext()
+	ctx.Limits.MaxDepth = 3
+	var f Function
+	calls := 0
+	f = NewFunction(ctx.Namespace, NewSignature(Value{}, evaluator(func(c *Context) (Value, error) {
+		calls++
+		return f.Eval(c, nil)
+	}), nil))
+	_, err := f.Eval(ctx, nil)
+	checkLimit(t, err, "depth")
+	if calls != 3 {
+		t.Errorf("function called %d times, want 3", calls)
+	}
+}
+
+func TestOperator_Apply(t *testing.T) {
+	ctx := NewContext()
+	ctx.Limits.MaxDepth = 1
+	ctx.Stack = []Frame{{Function: "f"}}
+	depth := 0
+	body := NewSignature(Value{}, evaluator(func(c *Context) (Value, error) {
+		depth = len(c.Stack)
+		return Value{}, nil
+	}), nil)
+	builtin := Operator{Function: NewFunction(nil, body), Symbol: "+"}
+	if _, err := builtin.Apply(ctx, Frame{Function: "+"}, nil); err != nil {
+		t.Errorf("Apply() of built-in operator error = %v", err)
+	}
+	if depth != 1 {
+		t.Errorf("built-in operator evaluated at depth %d, want 1", depth)
+	}
+	defined := Operator{Function: NewFunction(ctx.Namespace, body), Symbol: "+"}
+	_, err := defined.Apply(ctx, Frame{Function: "+"}, nil)
+	checkLimit(t, err, "depth")
+	ctx.Limits.MaxDepth = 0
+	if _, err := defined.Apply(ctx, Frame{Function: "+"}, nil); err != nil {
+		t.Errorf("Apply() of defined operator error = %v", err)
+	}
+	if depth != 2 {
+		t.Errorf("defined operator evaluated at depth %d, want 2", depth)
+	}
+}
+
+type evaluator func(c *Context) (Value, error)
+
+func (e evaluator) Eval(c *Context) (Value, error) {
+	return e(c)
+}
+
+func checkLimit(t *testing.T, err error, limit string) {
+	t.Helper()
+	if limit == "" {
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		return
+	}
+	e, ok := errors.Cause(err).(*LimitError)
+	if !ok || e.Limit != limit {
+		t.Errorf("error = %v, want %s limit error", err, limit)
+	}
+}
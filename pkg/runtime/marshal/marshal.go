@@ -0,0 +1,472 @@
+// Package marshal converts between Go and Tea values using reflection.
+//
+// Booleans, integers, floats and strings map to the corresponding Tea types,
+// slices and arrays map to arrays and maps with string keys map to maps.
+// Structs are converted to maps of their exported fields, the keys can be changed using the tea struct tag:
+//
+//	type Point struct {
+//		X     int    `tea:"x"`
+//		Y     int    `tea:"y"`
+//		Label string `tea:"label,omitempty"`
+//		Cache []int  `tea:"-"`
+//	}
+//
+// Pointers and interfaces are dereferenced, nil values become null.
+// Values of time.Time and time.Duration map to the time and duration types,
+// big.Int to bigint and big.Rat to decimal.
+package marshal
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// Marshaler is implemented by Go types converting themselves into Tea values.
+type Marshaler interface {
+	MarshalTea() (runtime.Value, error)
+}
+
+var (
+	valueType     = reflect.TypeOf(runtime.Value{})
+	functionType  = reflect.TypeOf(runtime.Function{})
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	timeType      = reflect.TypeOf(time.Time{})
+	durationType  = reflect.TypeOf(time.Duration(0))
+	bigIntType    = reflect.TypeOf(big.Int{})
+	ratType       = reflect.TypeOf(big.Rat{})
+	rationalType  = reflect.TypeOf(types.Rational{})
+)
+
+// Converter converts between Go and Tea values.
+// Functions are converted using the optional hooks, without them only runtime.Function is supported.
+type Converter struct {
+	// Wrap converts a Go function into a Tea function value.
+	Wrap func(fn reflect.Value) (runtime.Value, error)
+	// Unwrap converts a Tea function value into a Go value of the given type.
+	// It is used for all types except runtime.Value and runtime.Function.
+	Unwrap func(value runtime.Value, t reflect.Type) (reflect.Value, error)
+}
+
+// field is an exported struct field and the key it is stored with.
+type field struct {
+	index     int
+	key       string
+	omitEmpty bool
+}
+
+// fields lists the fields of the struct type which are converted.
+func fields(t reflect.Type) []field {
+	list := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("tea")
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		options := strings.Split(tag, ",")
+		key := options[0]
+		if key == "" {
+			key = f.Name
+		}
+		omitEmpty := false
+		for _, option := range options[1:] {
+			omitEmpty = omitEmpty || option == "omitempty"
+		}
+		list = append(list, field{index: i, key: key, omitEmpty: omitEmpty})
+	}
+	return list
+}
+
+// describe names the type of the Tea value for error messages.
+func describe(value runtime.Value) string {
+	if value.Type == nil {
+		return "null"
+	}
+	return value.Typeflag.String()
+}
+
+// Typeflag returns the typeflag of Tea values converted from the Go type.
+func (c Converter) Typeflag(t reflect.Type) (runtime.Typeflag, error) {
+	switch {
+	case t == valueType, t.Implements(marshalerType):
+		return runtime.T(types.Any), nil
+	case t == functionType:
+		return runtime.T(types.Function), nil
+	case t == timeType:
+		return runtime.T(types.Time), nil
+	case t == durationType:
+		return runtime.T(types.Duration), nil
+	case t == bigIntType:
+		return runtime.T(types.BigInt), nil
+	case t == ratType, t == rationalType:
+		return runtime.T(types.Decimal), nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return runtime.T(types.Bool), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return runtime.T(types.Integer), nil
+	case reflect.Float32, reflect.Float64:
+		return runtime.T(types.Float), nil
+	case reflect.String:
+		return runtime.T(types.String), nil
+	case reflect.Func:
+		return runtime.T(types.Function), nil
+	case reflect.Interface:
+		return runtime.T(types.Any), nil
+	case reflect.Ptr:
+		return c.Typeflag(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem, err := c.Typeflag(t.Elem())
+		if err != nil {
+			return runtime.Typeflag{}, err
+		}
+		return runtime.Typeflag{Type: types.Array, Params: []runtime.Typeflag{elem}}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return runtime.Typeflag{}, errors.Errorf("unsupported Go type %s: map keys must be strings", t)
+		}
+		elem, err := c.Typeflag(t.Elem())
+		if err != nil {
+			return runtime.Typeflag{}, err
+		}
+		return runtime.Typeflag{Type: types.Map, Params: []runtime.Typeflag{elem}}, nil
+	case reflect.Struct:
+		return runtime.T(types.Map, types.Any), nil
+	}
+	return runtime.Typeflag{}, errors.Errorf("unsupported Go type %s", t)
+}
+
+// pointer returns a pointer to the value, unaddressable values are copied.
+func pointer(rv reflect.Value) interface{} {
+	if rv.CanAddr() {
+		return rv.Addr().Interface()
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr.Interface()
+}
+
+// ToValue converts the Go value into a Tea value.
+func (c Converter) ToValue(v interface{}) (runtime.Value, error) {
+	return c.toValue(reflect.ValueOf(v))
+}
+
+func (c Converter) toValue(rv reflect.Value) (runtime.Value, error) {
+	if !rv.IsValid() {
+		return runtime.Value{}, nil
+	}
+	t := rv.Type()
+	switch {
+	case t == valueType:
+		return rv.Interface().(runtime.Value), nil
+	case t == functionType:
+		return runtime.Value{Typeflag: runtime.T(types.Function), Data: rv.Interface()}, nil
+	case t == timeType:
+		return runtime.Value{Typeflag: runtime.T(types.Time), Data: rv.Interface()}, nil
+	case t == durationType:
+		return runtime.Value{Typeflag: runtime.T(types.Duration), Data: rv.Interface()}, nil
+	case t == bigIntType:
+		return runtime.Value{Typeflag: runtime.T(types.BigInt), Data: new(big.Int).Set(pointer(rv).(*big.Int))}, nil
+	case t == ratType, t == rationalType:
+		r := pointer(rv.Convert(ratType)).(*big.Rat)
+		return runtime.Value{Typeflag: runtime.T(types.Decimal), Data: (*types.Rational)(new(big.Rat).Set(r))}, nil
+	case t.Implements(marshalerType) && rv.CanInterface():
+		if t.Kind() == reflect.Ptr && rv.IsNil() {
+			return runtime.Value{}, nil
+		}
+		return rv.Interface().(Marshaler).MarshalTea()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return runtime.Value{Typeflag: runtime.T(types.Bool), Data: rv.Bool()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return runtime.Value{Typeflag: runtime.T(types.Integer), Data: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return runtime.Value{}, errors.Errorf("value %d overflows int", rv.Uint())
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Integer), Data: int64(rv.Uint())}, nil
+	case reflect.Float32, reflect.Float64:
+		return runtime.Value{Typeflag: runtime.T(types.Float), Data: rv.Float()}, nil
+	case reflect.String:
+		return runtime.Value{Typeflag: runtime.T(types.String), Data: rv.String()}, nil
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return runtime.Value{}, nil
+		}
+		return c.toValue(rv.Elem())
+	case reflect.Func:
+		if c.Wrap == nil {
+			return runtime.Value{}, errors.Errorf("unsupported Go type %s", t)
+		}
+		if rv.IsNil() {
+			return runtime.Value{}, nil
+		}
+		return c.Wrap(rv)
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && rv.IsNil() {
+			return runtime.Value{}, nil
+		}
+		typeflag, err := c.Typeflag(t)
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		items := make([]runtime.Value, rv.Len())
+		for i := range items {
+			if items[i], err = c.toValue(rv.Index(i)); err != nil {
+				return runtime.Value{}, errors.Wrapf(err, "index %d", i)
+			}
+		}
+		return runtime.Value{Typeflag: typeflag, Data: items}, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return runtime.Value{}, nil
+		}
+		typeflag, err := c.Typeflag(t)
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		items := make(map[string]runtime.Value, rv.Len())
+		for _, key := range rv.MapKeys() {
+			item, err := c.toValue(rv.MapIndex(key))
+			if err != nil {
+				return runtime.Value{}, errors.Wrapf(err, "key %s", key)
+			}
+			items[key.String()] = item.Rename(key.String())
+		}
+		return runtime.Value{Typeflag: typeflag, Data: items}, nil
+	case reflect.Struct:
+		items := make(map[string]runtime.Value)
+		for _, f := range fields(t) {
+			fv := rv.Field(f.index)
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			item, err := c.toValue(fv)
+			if err != nil {
+				return runtime.Value{}, errors.Wrapf(err, "field %s", t.Field(f.index).Name)
+			}
+			items[f.key] = item.Rename(f.key)
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Map, types.Any), Data: items}, nil
+	}
+	return runtime.Value{}, errors.Errorf("unsupported Go type %s", t)
+}
+
+// FromValue stores the Tea value in the Go value pointed to by target.
+func (c Converter) FromValue(value runtime.Value, target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.Errorf("expected non-nil pointer as target, got %T", target)
+	}
+	converted, err := c.Convert(value, rv.Type().Elem())
+	if err != nil {
+		return err
+	}
+	rv.Elem().Set(converted)
+	return nil
+}
+
+// Export converts the Tea value into its natural Go representation.
+// Arrays are exported as []interface{} and maps as map[string]interface{}.
+func (c Converter) Export(value runtime.Value) (interface{}, error) {
+	rv, err := c.Convert(value, interfaceType)
+	if err != nil {
+		return nil, err
+	}
+	return rv.Interface(), nil
+}
+
+// Convert converts the Tea value into a Go value of the given type.
+func (c Converter) Convert(value runtime.Value, t reflect.Type) (reflect.Value, error) {
+	value = types.Underlying(value)
+	if value.Data == nil && (value.Type == types.BigInt || value.Type == types.Decimal) {
+		// null numbers keep their datatype
+		value = runtime.Value{}
+	}
+	switch {
+	case t == valueType:
+		return reflect.ValueOf(value), nil
+	case t == functionType && value.Type == types.Function:
+		return reflect.ValueOf(value.Data), nil
+	case value.Type == types.Function && c.Unwrap != nil:
+		return c.Unwrap(value, t)
+	case value.Type == nil:
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, errors.Errorf("can not convert null to %s", t)
+	}
+	mismatch := func() error {
+		return errors.Errorf("can not convert %s to %s", describe(value), t)
+	}
+	rv := reflect.New(t).Elem()
+	switch {
+	case t == timeType, t == durationType:
+		typeflag, _ := c.Typeflag(t)
+		if value.Type != typeflag.Type {
+			return reflect.Value{}, mismatch()
+		}
+		rv.Set(reflect.ValueOf(value.Data))
+		return rv, nil
+	case t == bigIntType:
+		// arbitrary-precision numbers are copied, so changes of the Go value do not affect the Tea value
+		if value.Type != types.BigInt {
+			return reflect.Value{}, mismatch()
+		}
+		rv.Set(reflect.ValueOf(*new(big.Int).Set(value.Data.(*big.Int))))
+		return rv, nil
+	case t == ratType, t == rationalType:
+		if value.Type != types.Decimal {
+			return reflect.Value{}, mismatch()
+		}
+		rv.Set(reflect.ValueOf(*new(big.Rat).Set(value.Data.(*types.Rational).Rat())).Convert(t))
+		return rv, nil
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		exported, err := c.export(value)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !exported.Type().Implements(t) {
+			return reflect.Value{}, mismatch()
+		}
+		rv.Set(exported)
+	case reflect.Ptr:
+		elem, err := c.Convert(value, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		rv.Set(reflect.New(t.Elem()))
+		rv.Elem().Set(elem)
+	case reflect.Bool:
+		if value.Type != types.Bool {
+			return reflect.Value{}, mismatch()
+		}
+		rv.SetBool(value.Data.(bool))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.Type != types.Integer {
+			return reflect.Value{}, mismatch()
+		}
+		i := value.Data.(int64)
+		if rv.OverflowInt(i) {
+			return reflect.Value{}, errors.Errorf("value %d overflows %s", i, t)
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.Type != types.Integer {
+			return reflect.Value{}, mismatch()
+		}
+		i := value.Data.(int64)
+		if i < 0 || rv.OverflowUint(uint64(i)) {
+			return reflect.Value{}, errors.Errorf("value %d overflows %s", i, t)
+		}
+		rv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		switch value.Type {
+		case types.Float:
+			rv.SetFloat(value.Data.(float64))
+		case types.Integer:
+			rv.SetFloat(float64(value.Data.(int64)))
+		default:
+			return reflect.Value{}, mismatch()
+		}
+	case reflect.String:
+		if value.Type != types.String {
+			return reflect.Value{}, mismatch()
+		}
+		rv.SetString(value.Data.(string))
+	case reflect.Slice, reflect.Array:
+		items, ok := value.Data.([]runtime.Value)
+		if value.Type != types.Array || (!ok && value.Data != nil) {
+			return reflect.Value{}, mismatch()
+		}
+		if t.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(t, len(items), len(items)))
+		} else if len(items) > t.Len() {
+			return reflect.Value{}, errors.Errorf("can not convert array of length %d to %s", len(items), t)
+		}
+		for i, item := range items {
+			elem, err := c.Convert(item, t.Elem())
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "index %d", i)
+			}
+			rv.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		items, ok := value.Data.(map[string]runtime.Value)
+		if value.Type != types.Map || (!ok && value.Data != nil) || t.Key().Kind() != reflect.String {
+			return reflect.Value{}, mismatch()
+		}
+		rv.Set(reflect.MakeMapWithSize(t, len(items)))
+		for key, item := range items {
+			elem, err := c.Convert(item, t.Elem())
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "key %s", key)
+			}
+			rv.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elem)
+		}
+	case reflect.Struct:
+		items, ok := value.Data.(map[string]runtime.Value)
+		if value.Type != types.Map || (!ok && value.Data != nil) {
+			return reflect.Value{}, mismatch()
+		}
+		for _, f := range fields(t) {
+			item, ok := items[f.key]
+			if !ok {
+				continue
+			}
+			elem, err := c.Convert(item, t.Field(f.index).Type)
+			if err != nil {
+				return reflect.Value{}, errors.Wrapf(err, "field %s", t.Field(f.index).Name)
+			}
+			rv.Field(f.index).Set(elem)
+		}
+	default:
+		return reflect.Value{}, mismatch()
+	}
+	return rv, nil
+}
+
+// export converts the Tea value into a Go value for an interface type.
+func (c Converter) export(value runtime.Value) (reflect.Value, error) {
+	switch value.Type {
+	case types.Array:
+		return c.Convert(value, reflect.TypeOf([]interface{}{}))
+	case types.Map:
+		return c.Convert(value, reflect.TypeOf(map[string]interface{}{}))
+	}
+	return reflect.ValueOf(value.Data), nil
+}
+
+// Typeflag returns the typeflag of Tea values converted from the Go type.
+func Typeflag(t reflect.Type) (runtime.Typeflag, error) {
+	return Converter{}.Typeflag(t)
+}
+
+// ToValue converts the Go value into a Tea value.
+func ToValue(v interface{}) (runtime.Value, error) {
+	return Converter{}.ToValue(v)
+}
+
+// FromValue stores the Tea value in the Go value pointed to by target.
+func FromValue(value runtime.Value, target interface{}) error {
+	return Converter{}.FromValue(value, target)
+}
+
+// Export converts the Tea value into its natural Go representation.
+func Export(value runtime.Value) (interface{}, error) {
+	return Converter{}.Export(value)
+}
@@ -0,0 +1,155 @@
+package marshal
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+type point struct {
+	X      int      `tea:"x"`
+	Y      int      `tea:"y"`
+	Label  string   `tea:"label,omitempty"`
+	Tags   []string `tea:"tags"`
+	Cache  int      `tea:"-"`
+	hidden int
+}
+
+func TestConverter_RoundTrip(t *testing.T) {
+	label := "origin"
+	tests := []struct {
+		name     string
+		value    interface{}
+		typeflag string
+	}{
+		{"Bool", true, "bool"},
+		{"Int", int8(-3), "int"},
+		{"Uint", uint16(7), "int"},
+		{"Float", 1.5, "float"},
+		{"String", "tea", "string"},
+		{"Pointer", &label, "string"},
+		{"Slice", []int{1, 2, 3}, "array<int>"},
+		{"Array", [2]string{"a", "b"}, "array<string>"},
+		{"Nested", [][]float64{{1}, {2, 3}}, "array<array<float>>"},
+		{"Map", map[string]bool{"a": true}, "map<bool>"},
+		{"Struct", point{X: 1, Y: 2, Tags: []string{"a"}}, "map<any>"},
+		{"Time", time.Date(2018, 3, 4, 12, 0, 0, 0, time.UTC), "time"},
+		{"Duration", 90 * time.Second, "duration"},
+		{"Times", []time.Time{time.Unix(0, 0)}, "array<time>"},
+		{"BigInt", big.NewInt(-42), "bigint"},
+		{"Rat", big.NewRat(3, 4), "decimal"},
+		{"Rational", (*types.Rational)(big.NewRat(1, 8)), "decimal"},
+		{"BigInts", map[string]big.Int{"a": *big.NewInt(7)}, "map<bigint>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ToValue(tt.value)
+			if err != nil {
+				t.Fatalf("ToValue() error = %v", err)
+			}
+			if value.Typeflag.String() != tt.typeflag {
+				t.Errorf("ToValue() typeflag = %s, want %s", value.Typeflag, tt.typeflag)
+			}
+			typeflag, err := Typeflag(reflect.TypeOf(tt.value))
+			if err != nil || typeflag.String() != tt.typeflag {
+				t.Errorf("Typeflag() = %s, %v, want %s", typeflag, err, tt.typeflag)
+			}
+			target := reflect.New(reflect.TypeOf(tt.value))
+			if err := FromValue(value, target.Interface()); err != nil {
+				t.Fatalf("FromValue() error = %v", err)
+			}
+			if got := target.Elem().Interface(); !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("FromValue() = %#v, want %#v", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestToValue_Struct(t *testing.T) {
+	value, err := ToValue(point{X: 1, Cache: 5, hidden: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := value.Data.(map[string]runtime.Value)
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	if len(items) != 3 || items["x"].Data != int64(1) || items["tags"].Type != nil {
+		t.Errorf("ToValue() = %v, want keys x, y and tags", keys)
+	}
+}
+
+func TestExport(t *testing.T) {
+	value, err := ToValue(map[string]interface{}{
+		"list":  []interface{}{int64(1), "two", nil},
+		"value": 2.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// values cast to any are exported as their actual type
+	items := value.Data.(map[string]runtime.Value)
+	items["value"], _ = types.Any.Cast(items["value"], nil)
+	got, err := Export(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"list":  []interface{}{int64(1), "two", nil},
+		"value": 2.5,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Export() = %#v, want %#v", got, want)
+	}
+}
+
+func TestFromValue_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		target interface{}
+	}{
+		{"Mismatch", "tea", new(int)},
+		{"Overflow", 300, new(int8)},
+		{"Negative", -1, new(uint)},
+		{"Null", nil, new(string)},
+		{"ArrayLength", []int{1, 2, 3}, new([2]int)},
+		{"Element", []interface{}{1, "x"}, new([]int)},
+		{"Field", map[string]string{"x": "1"}, new(point)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ToValue(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := FromValue(value, tt.target); err == nil {
+				t.Errorf("FromValue() expected error")
+			}
+		})
+	}
+	if _, err := ToValue(map[int]int{1: 1}); err == nil {
+		t.Errorf("ToValue() of map with int keys expected error")
+	}
+	if _, err := ToValue(func() {}); err == nil {
+		t.Errorf("ToValue() of function without hook expected error")
+	}
+}
+
+func TestConvert_NullNumbers(t *testing.T) {
+	for _, datatype := range []*runtime.Datatype{types.BigInt, types.Decimal} {
+		value := runtime.Value{Typeflag: runtime.T(datatype)}
+		if got, err := Export(value); err != nil || got != nil {
+			t.Errorf("Export(null %s) = %v, %v, want nil", datatype, got, err)
+		}
+		n := big.NewInt(1)
+		if err := FromValue(value, &n); err != nil || n != nil {
+			t.Errorf("FromValue(null %s) = %v, %v, want nil", datatype, n, err)
+		}
+	}
+}
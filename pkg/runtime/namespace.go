@@ -171,6 +171,9 @@ func (v Value) Update(item SearchItem) (SearchItem, error) {
 	if !ok {
 		return v, errors.Errorf("expected value item, got %s", item)
 	}
+	if c.Type == nil {
+		return v, errors.Errorf("can not assign null to %s", v.Type)
+	}
 	if !c.Type.KindOf(v.Type) {
 		return v, errors.Errorf("can not assign type %s to %s", c.Type, v.Type)
 	}
@@ -196,6 +199,19 @@ type Operator struct {
 	Function
 	Symbol   string
 	Constant bool
+	// ShortCircuit decides the result from the first operand, so the remaining ones are not evaluated.
+	// It is nil for operators evaluating all operands.
+	ShortCircuit func(first Value) (Value, bool)
+}
+
+// Apply executes the operator on the arguments.
+// Operators defined by the program are put on the call stack using the frame,
+// built-in operators are executed without entering the call stack.
+func (o Operator) Apply(c *Context, frame Frame, args []Value) (Value, error) {
+	if o.Source != nil {
+		return o.Call(c, frame, args)
+	}
+	return o.call(c, nil, args)
 }
 
 // SearchSpace returns the operator search space.
@@ -224,6 +240,40 @@ func (o Operator) Update(item SearchItem) (SearchItem, error) {
 	return o, nil
 }
 
+// Module groups search items under a common name, e.g. the builtins of the fs module.
+// Its members are accessed using qualified names like fs.read_file.
+type Module struct {
+	Name    string
+	Members *Namespace
+}
+
+// SearchSpace returns the module search space.
+func (*Module) SearchSpace() SearchSpace {
+	return SearchModule
+}
+
+// Alias returns the name of the module.
+func (m *Module) Alias() string {
+	return m.Name
+}
+
+// Update fails.
+func (m *Module) Update(item SearchItem) (SearchItem, error) {
+	return m, errors.Errorf("module %s can not be changed", m.Name)
+}
+
+func (m *Module) String() string {
+	return m.Name
+}
+
+// NewModule constructs a new module without any members.
+func NewModule(name string) *Module {
+	return &Module{
+		Name:    name,
+		Members: NewNamespace(nil),
+	}
+}
+
 // SearchItem is a generic item that can be stored in a namespace.
 type SearchItem interface {
 	SearchSpace() SearchSpace
@@ -241,6 +291,8 @@ const (
 	SearchOperator
 	// SearchDatatype is used for datatypes.
 	SearchDatatype
+	// SearchModule is used for modules.
+	SearchModule
 )
 
 var (
@@ -249,6 +301,7 @@ var (
 		SearchIdentifier,
 		SearchOperator,
 		SearchDatatype,
+		SearchModule,
 	}
 )
 
@@ -271,6 +324,20 @@ func (ns *Namespace) Find(space SearchSpace, alias string) (SearchItem, error) {
 	return item, nil
 }
 
+// FindMember looks for the module in this namespace and its parent,
+// followed by the search item in the given search space of the module members.
+func (ns *Namespace) FindMember(space SearchSpace, module, alias string) (SearchItem, error) {
+	item, err := ns.Find(SearchModule, module)
+	if err != nil {
+		return nil, errors.Errorf("module %s not found in namespace", module)
+	}
+	member, err := item.(*Module).Members.Find(space, alias)
+	if err != nil {
+		return nil, errors.Errorf("item %s not found in module %s", alias, module)
+	}
+	return member, nil
+}
+
 // Update looks for and updates the search item in this namespace and its parent.
 func (ns *Namespace) Update(item SearchItem) error {
 	existing, ok := ns.Storage[item.SearchSpace()][item.Alias()]
@@ -298,6 +365,19 @@ func (ns *Namespace) Store(item SearchItem) error {
 	return nil
 }
 
+// Overload adds the signatures of the operator to the operator of the same symbol in this namespace.
+// The added signatures take precedence, so they can specialize generic ones like the equality of any values.
+// If there is none, the operator is stored.
+func (ns *Namespace) Overload(op Operator) {
+	if existing, ok := ns.Storage[SearchOperator][op.Symbol].(Operator); ok {
+		signatures := make([]Signature, 0, len(existing.Signatures)+len(op.Signatures))
+		existing.Signatures = append(append(signatures, op.Signatures...), existing.Signatures...)
+		ns.Storage[SearchOperator][op.Symbol] = existing
+		return
+	}
+	ns.Storage[SearchOperator][op.Symbol] = op
+}
+
 // Child returns a new namespace that has this namespace as its parent.
 func (ns *Namespace) Child() *Namespace {
 	return NewNamespace(ns)
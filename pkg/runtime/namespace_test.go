@@ -0,0 +1,45 @@
+package runtime
+
+import "testing"
+
+func TestNamespace_FindMember(t *testing.T) {
+	global := NewNamespace(nil)
+	fs := NewModule("fs")
+	fs.Members.Store(Value{Name: "read_file", Data: "member"})
+	global.Store(fs)
+	global.Store(Value{Name: "fs", Data: "variable"})
+	local := NewNamespace(global)
+
+	tests := []struct {
+		name, module, alias string
+		want                interface{}
+		wantErr             bool
+	}{
+		{"Member", "fs", "read_file", "member", false},
+		{"Missing member", "fs", "write_file", nil, true},
+		{"Missing module", "path", "join", nil, true},
+		{"Variable of the same name", "", "fs", "variable", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				item SearchItem
+				err  error
+			)
+			if tt.module != "" {
+				item, err = local.FindMember(SearchIdentifier, tt.module, tt.alias)
+			} else {
+				item, err = local.Find(SearchIdentifier, tt.alias)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindMember() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && item.(Value).Data != tt.want {
+				t.Errorf("FindMember() = %v, want %v", item, tt.want)
+			}
+		})
+	}
+	if err := global.Update(NewModule("fs")); err == nil {
+		t.Error("Update() of module expected error")
+	}
+}
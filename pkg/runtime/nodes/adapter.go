@@ -13,6 +13,11 @@ func (Adapter) Name() string {
 	return "Adapter"
 }
 
+// Source returns an empty string, since adapters wrap native code that has no source representation.
+func (Adapter) Source() string {
+	return ""
+}
+
 // Eval executes the encapsulated function in the call context.
 func (a *Adapter) Eval(c *runtime.Context) (runtime.Value, error) {
 	return a.Func(c)
@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
@@ -25,6 +26,16 @@ func (a *Assignment) Graphviz(uid string) []string {
 	return a.BasicNode.Graphviz(uid)
 }
 
+// Values returns the list of nodes whose results are assigned to the aliases.
+func (a *Assignment) Values() []Node {
+	return a.Childs
+}
+
+// Source generates the Tea source code of the assignment.
+func (a *Assignment) Source() string {
+	return fmt.Sprintf("%s %s= %s", strings.Join(a.Alias, ", "), a.Operator, listSource(a.Childs))
+}
+
 // Eval executes the assignment by evaluating the value nodes and assigning the results to the values in the context namespace.
 func (a *Assignment) Eval(c *runtime.Context) (runtime.Value, error) {
 	if len(a.Childs) != len(a.Alias) {
@@ -37,7 +48,7 @@ func (a *Assignment) Eval(c *runtime.Context) (runtime.Value, error) {
 	// Step 1: generate values
 	results := make([]runtime.Value, len(a.Childs))
 	for i, node := range a.Childs {
-		result, err = node.Eval(c)
+		result, err = Evaluate(c, node)
 		if err != nil {
 			return runtime.Value{}, errors.Wrap(err, "failed to assign values")
 		}
@@ -65,7 +76,9 @@ func (a *Assignment) Eval(c *runtime.Context) (runtime.Value, error) {
 			if err != nil {
 				return runtime.Value{}, errors.Wrap(err, "failed to assign value")
 			}
-			result, err = operation.Eval(c, []runtime.Value{item.(runtime.Value), value})
+			line, column := Position(a)
+			frame := runtime.Frame{Function: a.Operator, Line: line, Column: column}
+			result, err = operation.Apply(c, frame, []runtime.Value{item.(runtime.Value), value})
 			if err != nil {
 				return runtime.Value{}, errors.Wrap(err, "failed to assign value")
 			}
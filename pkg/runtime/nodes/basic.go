@@ -3,7 +3,9 @@ package nodes
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
 )
 
@@ -16,6 +18,9 @@ type Node interface {
 	AddFront(child Node)
 	AddBack(child Node)
 	Graphviz(uid string) []string
+	Source() string
+	Children() []Node
+	Replace(old, replacement Node) error
 }
 
 // BasicNode provides a basic functionality for a new node.
@@ -56,6 +61,79 @@ func (n *BasicNode) AddFront(child Node) {
 	n.Childs = append([]Node{child}, n.Childs...)
 }
 
+// Children returns the list of child nodes.
+func (n *BasicNode) Children() []Node {
+	return n.Childs
+}
+
+// Replace swaps all occurrences of the old child with the replacement.
+// Since most nodes rely on a fixed number of children, the replacement must not be nil.
+// It fails if the old child has not been found.
+func (n *BasicNode) Replace(old, replacement Node) error {
+	if replacement == nil {
+		return errors.Errorf("can not remove child %s, node has a fixed number of children", old.Name())
+	}
+	return n.swap(old, replacement)
+}
+
+// swap replaces all occurrences of the old child with the replacement, removing them if the replacement is nil.
+func (n *BasicNode) swap(old, replacement Node) error {
+	found := false
+	childs := n.Childs[:0]
+	for _, c := range n.Childs {
+		if c != old {
+			childs = append(childs, c)
+			continue
+		}
+		found = true
+		if replacement != nil {
+			childs = append(childs, replacement)
+		}
+	}
+	n.Childs = childs
+	if !found {
+		return errors.Errorf("child %s not found", old.Name())
+	}
+	return nil
+}
+
+// Evaluate evaluates the node, notifying the tracer of the context before and afterwards.
+// It fails if the evaluation exceeds the resource limits of the context.
+func Evaluate(c *runtime.Context, n Node) (runtime.Value, error) {
+	if err := c.Charge(); err != nil {
+		return runtime.Value{}, err
+	}
+	if c.Tracer != nil {
+		c.Tracer.Enter(c, n)
+	}
+	value, err := n.Eval(c)
+	if err == nil {
+		err = c.Allocate(value)
+	}
+	if c.Tracer != nil {
+		c.Tracer.Leave(c, n, value, err)
+	}
+	return value, err
+}
+
+// Locate stores the source code position the node originates from in its metadata.
+func Locate(n Node, line, column int) {
+	n.Tag("line", strconv.Itoa(line))
+	n.Tag("column", strconv.Itoa(column))
+}
+
+// Position returns the line and column of the source code the node originates from.
+// If the position is unknown, both are zero.
+func Position(n Node) (line, column int) {
+	if v, ok := n.Has("line"); ok {
+		line, _ = strconv.Atoi(v)
+	}
+	if v, ok := n.Has("column"); ok {
+		column, _ = strconv.Atoi(v)
+	}
+	return line, column
+}
+
 // NewBasic constructs a new basic node that can not be evaluated.
 func NewBasic(childs ...Node) BasicNode {
 	return BasicNode{
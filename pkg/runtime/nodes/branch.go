@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
@@ -9,8 +10,17 @@ import (
 )
 
 // Branch executes a list of conditionals until the active conditional executes successfully.
+// Branches used as expressions yield the value of the executed arm, which must be compatible with the other arms.
+// Only arms ending with a literal have a statically known type, arms ending with identifiers, operations
+// or calls are checked against the known types when they are executed. Arms without a known type
+// are never checked against each other, e.g. `if c { x; } else { y; }` accepts any types of x and y.
 type Branch struct {
 	BasicNode
+	Expression bool
+
+	// known holds the statically known arm types, it is computed by Typecheck
+	// and derived again whenever the arms are replaced or rewritten.
+	known []runtime.Typeflag
 }
 
 // Name returns the name of the AST node.
@@ -18,11 +28,118 @@ func (Branch) Name() string {
 	return "Branch"
 }
 
+// Conditionals returns the list of conditionals in order of evaluation.
+func (b *Branch) Conditionals() []*Conditional {
+	conds := make([]*Conditional, 0, len(b.Childs))
+	for _, n := range b.Childs {
+		if cond, ok := n.(*Conditional); ok {
+			conds = append(conds, cond)
+		}
+	}
+	return conds
+}
+
+// Else returns the node executed if no conditional matches, nil if there is none.
+func (b *Branch) Else() Node {
+	for _, n := range b.Childs {
+		if _, ok := n.(*Conditional); !ok {
+			return n
+		}
+	}
+	return nil
+}
+
+// Arms returns the nodes yielding the result of the branch, the conditional bodies followed by the else node.
+func (b *Branch) Arms() []Node {
+	arms := make([]Node, 0, len(b.Childs))
+	for _, n := range b.Childs {
+		if cond, ok := n.(*Conditional); ok {
+			arms = append(arms, cond.Body())
+		} else {
+			arms = append(arms, n)
+		}
+	}
+	return arms
+}
+
+// Replace swaps the old conditional or else node with the replacement, a nil replacement removes it.
+func (b *Branch) Replace(old, replacement Node) error {
+	if err := b.swap(old, replacement); err != nil {
+		return err
+	}
+	b.refresh()
+	return nil
+}
+
+// refresh derives the statically known arm types from the current arms.
+func (b *Branch) refresh() {
+	b.known = armTypes(b.Arms())
+}
+
+// Typecheck verifies that all arms with a statically known result type yield compatible types.
+// The known types are kept to check the results of the other arms on evaluation.
+func (b *Branch) Typecheck() error {
+	known := armTypes(b.Arms())
+	for i := 1; i < len(known); i++ {
+		if !compatible(known[0], known[i]) {
+			return errors.Errorf("if expression arms yield incompatible types %s and %s", known[0], known[i])
+		}
+	}
+	b.known = known
+	return nil
+}
+
+// armTypes collects the result types of arms ending with a non-null literal.
+func armTypes(arms []Node) []runtime.Typeflag {
+	known := make([]runtime.Typeflag, 0, len(arms))
+	for _, arm := range arms {
+		for {
+			seq, ok := arm.(*Sequence)
+			if !ok || len(seq.Childs) == 0 {
+				break
+			}
+			arm = seq.Childs[len(seq.Childs)-1]
+		}
+		if lit, ok := arm.(*Literal); ok && lit.Value.Type != nil {
+			known = append(known, lit.Value.Typeflag)
+		}
+	}
+	return known
+}
+
+// compatible checks if one of the typeflags is of the kind of the other one, null is compatible with every type.
+func compatible(a, b runtime.Typeflag) bool {
+	if a.Type == nil || b.Type == nil {
+		return true
+	}
+	return a.Type.KindOf(b.Type) || b.Type.KindOf(a.Type)
+}
+
+// Source generates the Tea source code of the branch, chaining all conditionals using else.
+func (b *Branch) Source() string {
+	items := make([]string, len(b.Childs))
+	for i, n := range b.Childs {
+		if _, ok := n.(*Conditional); ok {
+			items[i] = n.Source()
+		} else {
+			items[i] = blockSource(n)
+		}
+	}
+	return strings.Join(items, " else ")
+}
+
 // Eval executes the branch by iterating over all children and evaluating the conditional.
 func (b *Branch) Eval(c *runtime.Context) (runtime.Value, error) {
 	for _, cond := range b.Childs {
-		value, err := cond.Eval(c)
+		value, err := Evaluate(c, cond)
 		if err == nil {
+			if b.Expression {
+				for _, known := range b.known {
+					if !compatible(value.Typeflag, known) {
+						return runtime.Value{}, errors.Errorf("if expression yields %s, expected type compatible to %s", value.Typeflag, known)
+					}
+				}
+			}
 			return value, nil
 		} else if _, ok := err.(conditionalException); !ok {
 			return runtime.Value{}, errors.Wrap(err, "failed to execute condition")
@@ -33,7 +150,7 @@ func (b *Branch) Eval(c *runtime.Context) (runtime.Value, error) {
 
 // NewBranch constructs a new branch node from the list of conditionals.
 func NewBranch(childs ...*Conditional) *Branch {
-	branch := &Branch{NewBasic()}
+	branch := &Branch{BasicNode: NewBasic()}
 	for _, c := range childs {
 		branch.AddBack(c)
 	}
@@ -66,10 +183,25 @@ func (cd *Conditional) Name() string {
 	return "Conditional"
 }
 
+// Condition returns the node evaluated to decide whether the body will be executed.
+func (cd *Conditional) Condition() Node {
+	return cd.Childs[0]
+}
+
+// Body returns the node executed if the condition results in true.
+func (cd *Conditional) Body() Node {
+	return cd.Childs[1]
+}
+
+// Source generates the Tea source code of the conditional.
+func (cd *Conditional) Source() string {
+	return fmt.Sprintf("if %s %s", cd.Childs[0].Source(), blockSource(cd.Childs[1]))
+}
+
 // Eval executes the conditional by first evaluating the condition and if it results in 'true', executing the body.
 func (cd *Conditional) Eval(c *runtime.Context) (runtime.Value, error) {
 	condition, body := cd.Childs[0], cd.Childs[1]
-	value, err := condition.Eval(c)
+	value, err := Evaluate(c, condition)
 	if err != nil {
 		return runtime.Value{}, errors.Wrap(err, "failed evaluating conditional")
 	}
@@ -80,7 +212,9 @@ func (cd *Conditional) Eval(c *runtime.Context) (runtime.Value, error) {
 		return runtime.Value{}, conditionalException{}
 	}
 
-	return c.Substitute(body.Eval)
+	return c.Substitute(func(c *runtime.Context) (runtime.Value, error) {
+		return Evaluate(c, body)
+	})
 }
 
 // NewConditional constructs a new conditional from the given condition and body nodes.
@@ -0,0 +1,75 @@
+package nodes
+
+import (
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestBranch_Typecheck(t *testing.T) {
+	str := NewLiteral(runtime.Value{Typeflag: runtime.T(types.String), Data: "a", Constant: true})
+	tests := []struct {
+		name         string
+		then, other  Node
+		wantCheckErr bool
+		wantEvalErr  bool
+	}{
+		{"Compatible literals", integer(1), integer(2), false, false},
+		{"Incompatible literals", integer(1), str, true, false},
+		{"Null literal", integer(1), NewLiteral(runtime.Value{}), false, false},
+		{"Identifier against literal", NewIdentifier("x"), str, false, true},
+		{"Compatible identifier", NewIdentifier("x"), integer(2), false, false},
+		// arms without a statically known type are not checked against each other
+		{"Identifiers", NewIdentifier("x"), NewIdentifier("s"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			branch := NewBranch(NewConditional(NewIdentifier("c"), NewSequence(true, tt.then)))
+			branch.AddBack(NewSequence(true, tt.other))
+			branch.Expression = true
+			if err := branch.Typecheck(); (err != nil) != tt.wantCheckErr {
+				t.Fatalf("Typecheck() error = %v, wantErr %v", err, tt.wantCheckErr)
+			}
+			if tt.wantCheckErr {
+				return
+			}
+			c := runtime.NewContext()
+			c.Namespace.Store(runtime.Value{Name: "c", Typeflag: runtime.T(types.Bool), Data: true})
+			c.Namespace.Store(runtime.Value{Name: "x", Typeflag: runtime.T(types.Integer), Data: int64(1)})
+			c.Namespace.Store(runtime.Value{Name: "s", Typeflag: runtime.T(types.String), Data: "s"})
+			if _, err := branch.Eval(c); (err != nil) != tt.wantEvalErr {
+				t.Errorf("Eval() error = %v, wantErr %v", err, tt.wantEvalErr)
+			}
+		})
+	}
+}
+
+func TestBranch_Rewrite(t *testing.T) {
+	// both arms yield integers until they are rewritten to yield strings
+	branch := NewBranch(NewConditional(NewIdentifier("c"), NewSequence(true, integer(1))))
+	branch.AddBack(NewSequence(true, integer(2)))
+	branch.Expression = true
+	if err := branch.Typecheck(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Rewrite(func(n Node) Node {
+		if lit, ok := n.(*Literal); ok && lit.Value.Type == types.Integer {
+			return NewLiteral(runtime.Value{Typeflag: runtime.T(types.String), Data: "a", Constant: true})
+		}
+		return n
+	}, branch); err != nil {
+		t.Fatal(err)
+	}
+	c := runtime.NewContext()
+	c.Namespace.Store(runtime.Value{Name: "c", Typeflag: runtime.T(types.Bool), Data: false})
+	if got, err := branch.Eval(c); err != nil || got.Data != "a" {
+		t.Errorf("Eval() = %v, %v, want a", got, err)
+	}
+	if err := branch.Replace(branch.Else(), NewSequence(true, integer(3))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := branch.Eval(c); err == nil {
+		t.Error("Eval() of integer arm against string arm expected error")
+	}
+}
@@ -16,6 +16,29 @@ func (Controller) Name() string {
 	return "Controller"
 }
 
+// Values returns the list of nodes evaluated before changing the behavior, e.g. the returned value.
+func (ctrl *Controller) Values() []Node {
+	return ctrl.Childs
+}
+
+// Source generates the Tea source code of the controller statement.
+func (ctrl *Controller) Source() string {
+	switch ctrl.Behavior {
+	case runtime.BehaviorContinue:
+		return continueKeyword
+	case runtime.BehaviorBreak:
+		return breakKeyword
+	case runtime.BehaviorFallthrough:
+		return fallthroughKeyword
+	case runtime.BehaviorReturn:
+		if len(ctrl.Childs) > 0 {
+			return returnKeyword + " " + listSource(ctrl.Childs)
+		}
+		return returnKeyword
+	}
+	return listSource(ctrl.Childs)
+}
+
 // Eval executes the controller by first evaluating all children, changing the behavior to the target behavior and then returning the last result.
 func (ctrl *Controller) Eval(c *runtime.Context) (runtime.Value, error) {
 	var (
@@ -23,7 +46,7 @@ func (ctrl *Controller) Eval(c *runtime.Context) (runtime.Value, error) {
 		err   error
 	)
 	for _, n := range ctrl.Childs {
-		value, err = n.Eval(c)
+		value, err = Evaluate(c, n)
 		if err != nil {
 			return value, errors.Wrap(err, "failed evaluating controller")
 		}
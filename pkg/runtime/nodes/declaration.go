@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
@@ -25,6 +26,61 @@ func (Declaration) Name() string {
 	return "Declaration"
 }
 
+// Values returns the list of nodes whose results are bound to the aliases.
+func (a *Declaration) Values() []Node {
+	return a.Childs
+}
+
+// isFunction checks if the declaration binds a single function literal to a constant.
+func (a *Declaration) isFunction() bool {
+	if !a.Constant || len(a.Alias) != 1 || len(a.Childs) != 1 {
+		return false
+	}
+	_, ok := a.Childs[0].(*FunctionLiteral)
+	return ok
+}
+
+// Source generates the Tea source code of the declaration.
+// Constant function literals are rendered as function definitions.
+func (a *Declaration) Source() string {
+	if a.isFunction() {
+		return a.Childs[0].(*FunctionLiteral).definitionSource(functionKeyword + " " + a.Alias[0])
+	}
+	keyword := variableKeyword
+	if a.Constant {
+		keyword = constantKeyword
+	}
+	var (
+		names    = make([]string, len(a.Alias))
+		values   = make([]string, len(a.Childs))
+		defaults = len(a.Childs) == len(a.Alias)
+	)
+	for i := range a.Alias {
+		names[i] = a.Alias[i]
+		if i >= len(a.Childs) {
+			continue
+		}
+		value := a.Childs[i]
+		if t, ok := value.(*Type); ok {
+			names[i] += ": " + t.Tree.String()
+			if value = t.Value(); value == nil {
+				values[i] = nullKeyword
+				continue
+			}
+		} else {
+			defaults = false
+		}
+		if lit, ok := value.(*Literal); !ok || lit.Value.Type != nil {
+			defaults = false
+		}
+		values[i] = value.Source()
+	}
+	if defaults {
+		return fmt.Sprintf("%s %s", keyword, strings.Join(names, ", "))
+	}
+	return fmt.Sprintf("%s %s = %s", keyword, strings.Join(names, ", "), strings.Join(values, ", "))
+}
+
 // Eval executes the declaration by first retrieving the values to be assigned and then storing them in the context namespace.
 func (a *Declaration) Eval(c *runtime.Context) (runtime.Value, error) {
 	if len(a.Childs) != len(a.Alias) {
@@ -37,7 +93,7 @@ func (a *Declaration) Eval(c *runtime.Context) (runtime.Value, error) {
 	// Step 1: generate values
 	results := make([]runtime.Value, len(a.Childs))
 	for i, node := range a.Childs {
-		value, err = node.Eval(c)
+		value, err = Evaluate(c, node)
 		if err != nil {
 			return runtime.Value{}, errors.Wrap(err, "failed declaring values")
 		}
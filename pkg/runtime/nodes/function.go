@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
@@ -9,9 +10,11 @@ import (
 )
 
 // FunctionCall calls a function with the evaluated children as parameters.
+// If the module is set, the alias refers to a member of the module.
 type FunctionCall struct {
 	BasicNode
-	Alias string
+	Alias  string
+	Module string
 }
 
 // Name returns the name of the AST node.
@@ -21,7 +24,7 @@ func (FunctionCall) Name() string {
 
 // Eval calls the target function by first evaluating all its children and then feeding them as parameters to the function.
 func (call *FunctionCall) Eval(c *runtime.Context) (runtime.Value, error) {
-	item, err := c.Namespace.Find(runtime.SearchIdentifier, call.Alias)
+	item, err := find(c, call.Module, call.Alias)
 	if err != nil {
 		return runtime.Value{}, errors.Wrap(err, "undefined function")
 	}
@@ -38,13 +41,14 @@ func (call *FunctionCall) Eval(c *runtime.Context) (runtime.Value, error) {
 	}
 	values := make([]runtime.Value, len(call.Childs))
 	for i, n := range call.Childs {
-		v, err := n.Eval(c)
+		v, err := Evaluate(c, n)
 		if err != nil {
 			return runtime.Value{}, errors.Wrap(err, "can not call function")
 		}
 		values[i] = v
 	}
-	result, err := callable.Eval(c, values)
+	line, column := Position(call)
+	result, err := callable.Call(c, runtime.Frame{Function: call.Qualified(), Line: line, Column: column}, values)
 	if err != nil {
 		return runtime.Value{}, errors.Wrap(err, "function call failed")
 	}
@@ -52,6 +56,21 @@ func (call *FunctionCall) Eval(c *runtime.Context) (runtime.Value, error) {
 	return result, nil
 }
 
+// Arguments returns the list of nodes passed as arguments to the function.
+func (call *FunctionCall) Arguments() []Node {
+	return call.Childs
+}
+
+// Qualified returns the alias prefixed by the module, if there is one.
+func (call *FunctionCall) Qualified() string {
+	return qualified(call.Module, call.Alias)
+}
+
+// Source generates the Tea source code of the function call.
+func (call *FunctionCall) Source() string {
+	return fmt.Sprintf("%s(%s)", call.Qualified(), listSource(call.Childs))
+}
+
 // NewFunctionCall constructs a new function call of the given function alias.
 func NewFunctionCall(alias string, args ...Node) *FunctionCall {
 	call := &FunctionCall{
@@ -73,7 +92,7 @@ func (literal *FunctionLiteral) buildSignature(c *runtime.Context) (runtime.Sign
 	// load arg types
 	args := make([]runtime.Value, len(literal.Args))
 	for i, arg := range literal.Args {
-		value, err := arg.Eval(c)
+		value, err := Evaluate(c, arg)
 		if err != nil {
 			return runtime.Signature{}, errors.Wrap(err, "could not build signature")
 		}
@@ -82,7 +101,7 @@ func (literal *FunctionLiteral) buildSignature(c *runtime.Context) (runtime.Sign
 	returns := runtime.Value{}
 	// load return types
 	if literal.Returns != nil {
-		value, err := literal.Returns.Eval(c)
+		value, err := Evaluate(c, literal.Returns)
 		if err != nil {
 			return runtime.Signature{}, errors.Wrap(err, "failed generating return type")
 		}
@@ -105,6 +124,64 @@ func (literal *FunctionLiteral) Eval(c *runtime.Context) (runtime.Value, error)
 	}, nil
 }
 
+// Body returns the node executed when the function is called.
+func (literal *FunctionLiteral) Body() Node {
+	return literal.Childs[0]
+}
+
+// Children returns the parameter types, the return type and the body of the function literal.
+func (literal *FunctionLiteral) Children() []Node {
+	childs := make([]Node, 0, len(literal.Args)+2)
+	for _, arg := range literal.Args {
+		childs = append(childs, arg)
+	}
+	if literal.Returns != nil {
+		childs = append(childs, literal.Returns)
+	}
+	return append(childs, literal.Childs...)
+}
+
+// Replace swaps the old parameter type, return type or body with the replacement.
+// Parameter and return types can only be replaced by type nodes, none of the children can be removed.
+func (literal *FunctionLiteral) Replace(old, replacement Node) error {
+	for i, arg := range literal.Args {
+		if Node(arg) == old {
+			typed, ok := replacement.(*Type)
+			if !ok {
+				return errors.New("parameter type can only be replaced by a type")
+			}
+			literal.Args[i] = typed
+			return nil
+		}
+	}
+	if literal.Returns != nil && Node(literal.Returns) == old {
+		typed, ok := replacement.(*Type)
+		if !ok {
+			return errors.New("return type can only be replaced by a type")
+		}
+		literal.Returns = typed
+		return nil
+	}
+	return literal.BasicNode.Replace(old, replacement)
+}
+
+// definitionSource generates the source code of the parameter list, return type and body prefixed by the given head.
+func (literal *FunctionLiteral) definitionSource(head string) string {
+	args := make([]string, len(literal.Args))
+	for i, arg := range literal.Args {
+		args[i] = arg.Source()
+	}
+	if literal.Returns != nil {
+		return fmt.Sprintf("%s(%s): %s %s", head, strings.Join(args, ", "), literal.Returns.Tree, blockSource(literal.Childs[0]))
+	}
+	return fmt.Sprintf("%s(%s) %s", head, strings.Join(args, ", "), blockSource(literal.Childs[0]))
+}
+
+// Source generates the Tea source code of the function literal.
+func (literal *FunctionLiteral) Source() string {
+	return literal.definitionSource(functionKeyword)
+}
+
 // Name returns the name of the AST node.
 func (FunctionLiteral) Name() string {
 	return "FunctionLiteral"
@@ -140,6 +217,11 @@ func (OperatorDefinition) Name() string {
 	return "OperatorDefinition"
 }
 
+// Source generates the Tea source code of the operator definition.
+func (definition *OperatorDefinition) Source() string {
+	return definition.definitionSource(operatorKeyword + " " + definition.Symbol)
+}
+
 // Eval generates a function literal and stores in the context namespace as an operator.
 func (definition *OperatorDefinition) Eval(c *runtime.Context) (runtime.Value, error) {
 	signature, err := definition.buildSignature(c)
@@ -8,9 +8,11 @@ import (
 )
 
 // Identifier is node storing a value alias that can be evaluted to retrieve the associated value.
+// If the module is set, the alias refers to a member of the module.
 type Identifier struct {
 	BasicNode
-	Alias string
+	Alias  string
+	Module string
 }
 
 // Name returns the name of the AST node.
@@ -18,9 +20,19 @@ func (Identifier) Name() string {
 	return "Identifier"
 }
 
+// Qualified returns the alias prefixed by the module, if there is one.
+func (i *Identifier) Qualified() string {
+	return qualified(i.Module, i.Alias)
+}
+
+// Source returns the qualified alias of the identifier.
+func (i *Identifier) Source() string {
+	return i.Qualified()
+}
+
 // Eval retrieves the value associated with the alias in the given context namespace.
 func (i *Identifier) Eval(c *runtime.Context) (runtime.Value, error) {
-	item, err := c.Namespace.Find(runtime.SearchIdentifier, i.Alias)
+	item, err := find(c, i.Module, i.Alias)
 	if err != nil {
 		return runtime.Value{}, errors.Wrap(err, "failed evaluating identifier")
 	}
@@ -41,3 +53,19 @@ func NewIdentifier(alias string) *Identifier {
 	ident.Metadata["label"] = fmt.Sprintf("Identifier (alias=%s)", alias)
 	return ident
 }
+
+// qualified joins the module and the alias of a member using the member operator.
+func qualified(module, alias string) string {
+	if module == "" {
+		return alias
+	}
+	return module + memberOperator + alias
+}
+
+// find looks up the identifier in the namespace of the context, or in the module if it is set.
+func find(c *runtime.Context, module, alias string) (runtime.SearchItem, error) {
+	if module == "" {
+		return c.Namespace.Find(runtime.SearchIdentifier, alias)
+	}
+	return c.Namespace.FindMember(runtime.SearchIdentifier, module, alias)
+}
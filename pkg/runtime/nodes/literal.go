@@ -2,8 +2,11 @@ package nodes
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
 )
 
 // Literal returns a constant value when evaluated.
@@ -17,6 +20,27 @@ func (Literal) Name() string {
 	return "Literal"
 }
 
+// Source generates the Tea source code of the literal value.
+func (l *Literal) Source() string {
+	switch l.Value.Type {
+	case nil:
+		return nullKeyword
+	case types.String:
+		return fmt.Sprintf("\"%s\"", l.Value.Data)
+	case types.Float:
+		src := strconv.FormatFloat(l.Value.Data.(float64), 'f', -1, 64)
+		if !strings.Contains(src, ".") {
+			src += ".0"
+		}
+		return src
+	case types.BigInt:
+		return l.Value.String() + "n"
+	case types.Decimal:
+		return l.Value.String() + "d"
+	}
+	return l.Value.String()
+}
+
 // Eval returns the value of the literal.
 func (l *Literal) Eval(c *runtime.Context) (runtime.Value, error) {
 	return l.Value, nil
@@ -1,6 +1,9 @@
 package nodes
 
 import (
+	"fmt"
+
+	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
 )
 
@@ -14,12 +17,21 @@ func (Loop) Name() string {
 	return "Loop"
 }
 
+// Source generates the Tea source code of the loop.
+func (l *Loop) Source() string {
+	return fmt.Sprintf("for %s %s", l.Childs[0].Source(), blockSource(l.Childs[1]))
+}
+
 // Eval executes a conditional over and over until the condition is false.
 // The control flow can be manipulated using behavior control.
+// Before each iteration, the context is checked for cancellation.
 func (l *Loop) Eval(c *runtime.Context) (runtime.Value, error) {
 	value, err := l.Conditional.Eval(c)
 	_, ok := err.(conditionalException)
 	for !ok {
+		if err != nil {
+			return runtime.Value{}, errors.Wrap(err, "failed evaluating loop")
+		}
 		switch c.Behavior {
 		case runtime.BehaviorReturn:
 			return value, nil
@@ -27,6 +39,9 @@ func (l *Loop) Eval(c *runtime.Context) (runtime.Value, error) {
 			c.Behavior = runtime.BehaviorDefault
 			return runtime.Value{}, nil
 		default:
+			if err := c.Err(); err != nil {
+				return runtime.Value{}, err
+			}
 			value, err = l.Conditional.Eval(c)
 			_, ok = err.(conditionalException)
 		}
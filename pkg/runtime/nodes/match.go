@@ -1,6 +1,10 @@
 package nodes
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
 )
 
@@ -17,10 +21,61 @@ func (m *Match) Graphviz(uid string) []string {
 	return m.BasicNode.Graphviz(uid)
 }
 
+// Subject returns the node whose value is compared to the cases.
+func (m *Match) Subject() Node {
+	return m.Childs[0]
+}
+
+// Cases returns the list of cases in order of comparison.
+func (m *Match) Cases() []*Case {
+	cases := make([]*Case, 0, len(m.Childs)-1)
+	for _, n := range m.Childs[1:] {
+		if c, ok := n.(*Case); ok {
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// Default returns the node executed if no case matches, nil if there is none.
+func (m *Match) Default() Node {
+	for _, n := range m.Childs[1:] {
+		if _, ok := n.(*Case); !ok {
+			return n
+		}
+	}
+	return nil
+}
+
+// Replace swaps the old subject, case or default node with the replacement.
+// Cases and the default node are removed by a nil replacement, the subject must be kept.
+func (m *Match) Replace(old, replacement Node) error {
+	if replacement == nil && old == m.Childs[0] {
+		return errors.New("can not remove subject of match statement")
+	}
+	return m.swap(old, replacement)
+}
+
+// Source generates the Tea source code of the match statement including all cases.
+func (m *Match) Source() string {
+	cases := make([]string, len(m.Childs)-1)
+	for i, n := range m.Childs[1:] {
+		if _, ok := n.(*Case); ok {
+			cases[i] = n.Source()
+		} else {
+			cases[i] = defaultKeyword + " " + blockSource(n)
+		}
+	}
+	if len(cases) == 0 {
+		return fmt.Sprintf("%s %s {}", matchKeyword, m.Childs[0].Source())
+	}
+	return fmt.Sprintf("%s %s {\n%s\n}", matchKeyword, m.Childs[0].Source(), indent(strings.Join(cases, "\n")))
+}
+
 func (m *Match) Eval(c *runtime.Context) (runtime.Value, error) {
 	return c.Substitute(func(c *runtime.Context) (runtime.Value, error) {
 		var result runtime.Value
-		match, err := m.Childs[0].Eval(c)
+		match, err := Evaluate(c, m.Childs[0])
 		if err != nil {
 			return runtime.Value{}, err
 		}
@@ -32,13 +87,13 @@ func (m *Match) Eval(c *runtime.Context) (runtime.Value, error) {
 		for _, n := range m.Childs[1:] {
 			switch c.Behavior {
 			case runtime.BehaviorFallthrough:
-				result, err = n.Eval(c)
+				result, err = Evaluate(c, n)
 			default:
 				switch n := n.(type) {
 				case *Case:
 					result, err = n.EvalCompare(match, c)
 				default:
-					result, err = n.Eval(c)
+					result, err = Evaluate(c, n)
 				}
 			}
 			if err != nil {
@@ -76,19 +131,34 @@ func (c *Case) Graphviz(uid string) []string {
 	return c.BasicNode.Graphviz(uid)
 }
 
+// Value returns the node whose value is compared to the match subject.
+func (c *Case) Value() Node {
+	return c.Childs[0]
+}
+
+// Body returns the node executed if the case matches.
+func (c *Case) Body() Node {
+	return c.Childs[1]
+}
+
+// Source generates the Tea source code of the case.
+func (c *Case) Source() string {
+	return fmt.Sprintf("%s %s %s", caseKeyword, c.Childs[0].Source(), blockSource(c.Childs[1]))
+}
+
 func (c *Case) EvalCompare(match runtime.Value, ctx *runtime.Context) (runtime.Value, error) {
-	value, err := c.Childs[0].Eval(ctx)
+	value, err := Evaluate(ctx, c.Childs[0])
 	if err != nil {
 		return runtime.Value{}, err
 	}
 	if !value.EqualTo(match) {
 		return runtime.Value{}, conditionalException{}
 	}
-	return c.Childs[1].Eval(ctx)
+	return Evaluate(ctx, c.Childs[1])
 }
 
 func (c *Case) Eval(ctx *runtime.Context) (runtime.Value, error) {
-	return c.Childs[1].Eval(ctx)
+	return Evaluate(ctx, c.Childs[1])
 }
 
 func NewCase(value Node, body Node) *Case {
@@ -19,7 +19,25 @@ func (Operation) Name() string {
 	return "Operation"
 }
 
+// Operands returns the list of nodes passed as arguments to the operator.
+func (o *Operation) Operands() []Node {
+	return o.Childs
+}
+
+// Source generates the Tea source code of the operation.
+// Nested operations are enclosed in parentheses to preserve the evaluation order.
+func (o *Operation) Source() string {
+	switch len(o.Childs) {
+	case 1:
+		return o.Symbol + operandSource(o.Childs[0])
+	case 2:
+		return fmt.Sprintf("%s %s %s", operandSource(o.Childs[0]), o.Symbol, operandSource(o.Childs[1]))
+	}
+	return fmt.Sprintf("%s(%s)", o.Symbol, listSource(o.Childs))
+}
+
 // Eval executes the operator by first collecting the parameters and then calling associated function.
+// Operators short-circuiting on the first operand skip the evaluation of the remaining ones.
 func (o *Operation) Eval(c *runtime.Context) (runtime.Value, error) {
 	item, err := c.Namespace.Find(runtime.SearchOperator, o.Symbol)
 	if err != nil {
@@ -31,13 +49,20 @@ func (o *Operation) Eval(c *runtime.Context) (runtime.Value, error) {
 	}
 	args := make([]runtime.Value, len(o.Childs))
 	for i, n := range o.Childs {
-		v, err := n.Eval(c)
+		v, err := Evaluate(c, n)
 		if err != nil {
 			return runtime.Value{}, errors.Wrap(err, "could not execute operation")
 		}
 		args[i] = v
+		if i == 0 && op.ShortCircuit != nil && len(o.Childs) > 1 {
+			if result, ok := op.ShortCircuit(v); ok {
+				c.Behavior = runtime.BehaviorDefault
+				return result, nil
+			}
+		}
 	}
-	result, err := op.Eval(c, args)
+	line, column := Position(o)
+	result, err := op.Apply(c, runtime.Frame{Function: o.Symbol, Line: line, Column: column}, args)
 	if err != nil {
 		return runtime.Value{}, errors.Wrap(err, "operation "+o.Symbol+" failed")
 	}
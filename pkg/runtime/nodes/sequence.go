@@ -18,8 +18,37 @@ func (Sequence) Name() string {
 	return "Sequence"
 }
 
+// Statements returns the list of statements executed by the sequence.
+func (n *Sequence) Statements() []Node {
+	return n.Childs
+}
+
+// Replace swaps the old statement with the replacement, a nil replacement removes the statement.
+func (n *Sequence) Replace(old, replacement Node) error {
+	return n.swap(old, replacement)
+}
+
+// Source generates the Tea source code of the sequence.
+// Substituted sequences are rendered as blocks, C-style loops are restored to their three-tier head.
+func (n *Sequence) Source() string {
+	if !n.Substitute {
+		return statementsSource(n.Childs)
+	}
+	if len(n.Childs) == 2 {
+		if loop, ok := n.Childs[1].(*Loop); ok {
+			if body, ok := loop.Childs[1].(*Sequence); ok && !body.Substitute && len(body.Childs) == 2 {
+				if inner, ok := body.Childs[0].(*Sequence); ok && !inner.Substitute {
+					return fmt.Sprintf("for %s; %s; %s %s", n.Childs[0].Source(), loop.Childs[0].Source(), body.Childs[1].Source(), blockSource(inner))
+				}
+			}
+		}
+	}
+	return blockSource(n)
+}
+
 // Eval executes the sequence by evaluating its children one by one.
 // The control flow can be modified by using behavior control.
+// Errors of failing children are annotated with the call stack, unless an inner sequence already did so.
 func (n *Sequence) Eval(c *runtime.Context) (runtime.Value, error) {
 	var (
 		parent *runtime.Namespace
@@ -33,8 +62,17 @@ func (n *Sequence) Eval(c *runtime.Context) (runtime.Value, error) {
 	}
 	for _, node := range n.Childs {
 		c.Behavior = runtime.BehaviorDefault
-		value, err = node.Eval(c)
+		if c.Hook != nil {
+			if err := c.Hook.Step(c, node); err != nil {
+				return value, errors.Wrap(err, "execution stopped")
+			}
+		}
+		value, err = Evaluate(c, node)
 		if err != nil {
+			if runtime.TraceOf(err) == nil {
+				line, column := Position(node)
+				err = runtime.NewError(c, err, line, column)
+			}
 			return value, errors.Wrap(err, "failed evaluating sequence")
 		}
 		if c.Behavior != runtime.BehaviorDefault {
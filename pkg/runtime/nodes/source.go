@@ -0,0 +1,104 @@
+package nodes
+
+import (
+	"strings"
+)
+
+// Keywords used when generating source code.
+const (
+	constantKeyword    = "let"
+	variableKeyword    = "var"
+	returnKeyword      = "return"
+	breakKeyword       = "break"
+	continueKeyword    = "continue"
+	fallthroughKeyword = "fallthrough"
+	functionKeyword    = "func"
+	operatorKeyword    = "operator"
+	nullKeyword        = "null"
+	matchKeyword       = "match"
+	caseKeyword        = "case"
+	defaultKeyword     = "default"
+	memberOperator     = "."
+)
+
+// indentation is prepended once per nesting level when generating source code.
+const indentation = "    "
+
+// indent prefixes every non-empty line of the source code with one level of indentation.
+func indent(src string) string {
+	lines := strings.Split(src, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = indentation + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// terminated checks if the node has to be followed by an end statement when used as a statement.
+func terminated(n Node) bool {
+	switch n := n.(type) {
+	case *Sequence, *Branch, *Conditional, *Loop, *Match, *Case, *OperatorDefinition:
+		return false
+	case *Declaration:
+		return !n.isFunction()
+	}
+	return true
+}
+
+// statementSource generates the source code of a statement including its end statement if required.
+func statementSource(n Node) string {
+	if terminated(n) {
+		return n.Source() + ";"
+	}
+	return n.Source()
+}
+
+// statementsSource generates the source code of a list of statements, one per line.
+func statementsSource(childs []Node) string {
+	lines := make([]string, 0, len(childs))
+	for _, n := range childs {
+		if src := statementSource(n); src != "" {
+			lines = append(lines, src)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// blockSource generates the source code of a node as a block enclosed by curly braces.
+func blockSource(n Node) string {
+	var body string
+	if seq, ok := n.(*Sequence); ok {
+		body = statementsSource(seq.Childs)
+	} else {
+		body = statementSource(n)
+	}
+	if body == "" {
+		return "{}"
+	}
+	return "{\n" + indent(body) + "\n}"
+}
+
+// operandSource generates the source code of an operand, enclosing it in parentheses if it is not atomic.
+func operandSource(n Node) string {
+	switch n := n.(type) {
+	case *Operation:
+		if len(n.Childs) > 1 {
+			return "(" + n.Source() + ")"
+		}
+	case *Type:
+		return "(" + n.Source() + ")"
+	case *Branch:
+		return "(" + n.Source() + ")"
+	}
+	return n.Source()
+}
+
+// listSource generates a comma-separated list of the nodes source code.
+func listSource(childs []Node) string {
+	items := make([]string, len(childs))
+	for i, n := range childs {
+		items[i] = n.Source()
+	}
+	return strings.Join(items, ", ")
+}
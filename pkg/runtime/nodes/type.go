@@ -60,7 +60,7 @@ func (t *Type) Eval(c *runtime.Context) (runtime.Value, error) {
 		return runtime.Value{}, errors.Wrap(err, "type has no nil value")
 	}
 	for i := range t.Childs {
-		result, err = t.Childs[i].Eval(c)
+		result, err = Evaluate(c, t.Childs[i])
 		if err != nil {
 			return runtime.Value{}, errors.Wrap(err, "can not eval")
 		}
@@ -72,6 +72,27 @@ func (t *Type) Eval(c *runtime.Context) (runtime.Value, error) {
 	return result, nil
 }
 
+// Value returns the node whose result is casted, nil if the type is used without a value.
+func (t *Type) Value() Node {
+	if len(t.Childs) == 0 {
+		return nil
+	}
+	return t.Childs[len(t.Childs)-1]
+}
+
+// Source generates the Tea source code of the type cast.
+// Function parameters are rendered in the 'name: type' format.
+func (t *Type) Source() string {
+	value := t.Value()
+	if value == nil {
+		return t.Tree.String()
+	}
+	if lit, ok := value.(*Literal); ok && lit.Value.Type == nil && lit.Value.Name != "" {
+		return fmt.Sprintf("%s: %s", lit.Value.Name, t.Tree)
+	}
+	return fmt.Sprintf("%s: %s", operandSource(value), t.Tree)
+}
+
 func NewType(tree Typetree, args ...Node) *Type {
 	typed := &Type{
 		BasicNode: NewBasic(args...),
@@ -0,0 +1,68 @@
+package nodes
+
+import "github.com/pkg/errors"
+
+// Visitor is called for each node encountered by Walk.
+// If the returned visitor is not nil, Walk visits each of the children of the node with it,
+// followed by a call of Visit(nil).
+type Visitor interface {
+	Visit(node Node) Visitor
+}
+
+// Walk traverses the syntax tree in depth-first order.
+// It starts by calling v.Visit(node), node must not be nil.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	for _, c := range node.Children() {
+		Walk(v, c)
+	}
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the syntax tree in depth-first order, calling f for each node.
+// If f returns true, Inspect continues with the children of the node, followed by a call of f(nil).
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
+
+// Rewriter returns the node that takes the place of the given node within its parent.
+// Returning the given node keeps it, returning nil removes it from the parent.
+// Only statements of sequences, arms of branches and cases of matches can be removed.
+type Rewriter func(node Node) Node
+
+// Rewrite traverses the syntax tree in depth-first order, replacing the children of each node
+// before passing the node itself to the rewriter. It returns the rewritten root node.
+// It fails if a parent rejects the replacement of one of its children.
+func Rewrite(r Rewriter, node Node) (Node, error) {
+	childs := make([]Node, len(node.Children()))
+	copy(childs, node.Children())
+	for _, c := range childs {
+		replacement, err := Rewrite(r, c)
+		if err != nil {
+			return nil, err
+		}
+		if replacement == c {
+			continue
+		}
+		if err := node.Replace(c, replacement); err != nil {
+			return nil, errors.Wrapf(err, "can not rewrite %s", node.Name())
+		}
+	}
+	result := r(node)
+	if branch, ok := result.(*Branch); ok {
+		// the rewritten arms may yield other types than the ones known before
+		branch.refresh()
+	}
+	return result, nil
+}
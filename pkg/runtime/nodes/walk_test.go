@@ -0,0 +1,121 @@
+package nodes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func integer(i int64) *Literal {
+	return NewLiteral(runtime.Value{
+		Typeflag: runtime.T(types.Integer),
+		Data:     i,
+		Constant: true,
+	})
+}
+
+func TestInspect(t *testing.T) {
+	tree := NewSequence(false,
+		NewDeclaration("f", true, NewFunctionLiteral(
+			NewSequence(false, NewController(runtime.BehaviorReturn)),
+			NewType(Typetree{Name: "int"}),
+			NewType(Typetree{Name: "int"}, NewLiteral(runtime.Value{Name: "a"})),
+		)),
+		NewFunctionCall("f", NewOperation("+", 2, integer(1), NewIdentifier("x"))),
+	)
+	var names []string
+	Inspect(tree, func(n Node) bool {
+		if n != nil {
+			names = append(names, n.Name())
+		}
+		return true
+	})
+	want := []string{
+		"Sequence", "Declaration", "FunctionLiteral", "Type", "Literal", "Type", "Sequence", "Controller",
+		"FunctionCall", "Operation", "Literal", "Identifier",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Inspect() visited %v, want %v", names, want)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tree := NewSequence(false,
+		NewIdentifier("x"),
+		NewOperation("+", 2, NewIdentifier("x"), integer(2)),
+		NewController(runtime.BehaviorBreak),
+	)
+	got, err := Rewrite(func(n Node) Node {
+		switch n := n.(type) {
+		case *Identifier:
+			if n.Alias == "x" {
+				return integer(1)
+			}
+		case *Controller:
+			return nil
+		}
+		return n
+	}, tree)
+	if err != nil {
+		t.Fatalf("Rewrite() unexpected error %v", err)
+	}
+	if want := "1;\n1 + 2;"; got.Source() != want {
+		t.Errorf("Rewrite() = %q, want %q", got.Source(), want)
+	}
+}
+
+func TestRewrite_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		tree     Node
+		rewriter Rewriter
+	}{
+		{
+			"Remove conditional body",
+			NewBranch(NewConditional(NewIdentifier("c"), NewSequence(true, integer(1)))),
+			func(n Node) Node {
+				if seq, ok := n.(*Sequence); ok && seq.Substitute {
+					return nil
+				}
+				return n
+			},
+		},
+		{
+			"Remove operand",
+			NewSequence(false, NewOperation("+", 2, NewIdentifier("x"), integer(2))),
+			func(n Node) Node {
+				if _, ok := n.(*Identifier); ok {
+					return nil
+				}
+				return n
+			},
+		},
+		{
+			"Replace parameter type",
+			NewFunctionLiteral(
+				NewSequence(false),
+				nil,
+				NewType(Typetree{Name: "int"}, NewLiteral(runtime.Value{Name: "a"})),
+			),
+			func(n Node) Node {
+				if _, ok := n.(*Type); ok {
+					return NewIdentifier("int")
+				}
+				return n
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := tt.tree.Source()
+			if _, err := Rewrite(tt.rewriter, tt.tree); err == nil {
+				t.Errorf("Rewrite() expected error")
+			}
+			if got := tt.tree.Source(); got != source {
+				t.Errorf("Rewrite() changed tree to %q, want %q", got, source)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package operators
 
 import (
+	"math"
+
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
 	"github.com/tealang/core/pkg/runtime/nodes"
@@ -64,9 +66,13 @@ func loadMultiplication(c *runtime.Context) {
 		}
 		switch a.Type {
 		case types.Integer:
+			product, err := mulInt(c, a.Data.(int64), b.Data.(int64))
+			if err != nil {
+				return runtime.Value{}, err
+			}
 			return runtime.Value{
 				Typeflag: runtime.T(types.Integer),
-				Data:     a.Data.(int64) * b.Data.(int64),
+				Data:     product,
 			}, nil
 		case types.Float:
 			return runtime.Value{
@@ -182,9 +188,13 @@ func loadDivision(c *runtime.Context) {
 			if bv == 0 {
 				return runtime.Value{}, errors.New("can not divide by 0")
 			}
+			quotient, err := divInt(c, a.Data.(int64), bv)
+			if err != nil {
+				return runtime.Value{}, err
+			}
 			return runtime.Value{
 				Typeflag: runtime.T(types.Integer),
-				Data:     a.Data.(int64) / bv,
+				Data:     quotient,
 			}, nil
 		case types.Float:
 			bv := b.Data.(float64)
@@ -328,9 +338,13 @@ func loadAddition(c *runtime.Context) {
 		}
 		switch a.Type {
 		case types.Integer:
+			sum, err := addInt(c, a.Data.(int64), b.Data.(int64))
+			if err != nil {
+				return runtime.Value{}, err
+			}
 			return runtime.Value{
 				Typeflag: runtime.T(types.Integer),
-				Data:     a.Data.(int64) + b.Data.(int64),
+				Data:     sum,
 			}, nil
 		case types.Float:
 			return runtime.Value{
@@ -497,9 +511,13 @@ func loadSubtraction(c *runtime.Context) {
 				identA, _ = c.Namespace.Find(runtime.SearchIdentifier, "a")
 				a         = identA.(runtime.Value)
 			)
+			negated, err := negInt(c, a.Data.(int64))
+			if err != nil {
+				return runtime.Value{}, err
+			}
 			return runtime.Value{
 				Typeflag: runtime.T(types.Integer),
-				Data:     -a.Data.(int64),
+				Data:     negated,
 			}, nil
 		}),
 		Returns: runtime.Value{Typeflag: runtime.T(types.Integer)},
@@ -525,9 +543,13 @@ func loadSubtraction(c *runtime.Context) {
 		}
 		switch a.Type {
 		case types.Integer:
+			diff, err := subInt(c, a.Data.(int64), b.Data.(int64))
+			if err != nil {
+				return runtime.Value{}, err
+			}
 			return runtime.Value{
 				Typeflag: runtime.T(types.Integer),
-				Data:     a.Data.(int64) - b.Data.(int64),
+				Data:     diff,
 			}, nil
 		case types.Float:
 			return runtime.Value{
@@ -620,6 +642,109 @@ func loadSubtraction(c *runtime.Context) {
 	c.Namespace.Store(sub)
 }
 
+// floatOperands casts integer operands to float, so mixed operands can be handled as floats.
+func floatOperands(a, b runtime.Value) (float64, float64) {
+	af, _ := types.Float.Cast(a, nil)
+	bf, _ := types.Float.Cast(b, nil)
+	return af.Data.(float64), bf.Data.(float64)
+}
+
+// numericSignatures constructs the signatures of an operator on all combinations of int and float operands.
+func numericSignatures(adapter runtime.Evaluable) []runtime.Signature {
+	numeric := []*runtime.Datatype{types.Integer, types.Float}
+	signatures := make([]runtime.Signature, 0, len(numeric)*len(numeric))
+	for _, a := range numeric {
+		for _, b := range numeric {
+			signatures = append(signatures, runtime.NewSignature(runtime.Value{}, adapter, []runtime.Value{
+				{
+					Name:     "a",
+					Typeflag: runtime.T(a),
+					Constant: true,
+				},
+				{
+					Name:     "b",
+					Typeflag: runtime.T(b),
+					Constant: true,
+				},
+			}))
+		}
+	}
+	return signatures
+}
+
+// loadPower loads the power operator, integers raised to negative powers result in floats.
+func loadPower(c *runtime.Context) {
+	adapter := nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+		var (
+			identA, _ = c.Namespace.Find(runtime.SearchIdentifier, "a")
+			identB, _ = c.Namespace.Find(runtime.SearchIdentifier, "b")
+			a         = identA.(runtime.Value)
+			b         = identB.(runtime.Value)
+		)
+		if a.Type == types.Integer && b.Type == types.Integer && b.Data.(int64) >= 0 {
+			power, err := powInt(c, a.Data.(int64), b.Data.(int64))
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			return runtime.Value{
+				Typeflag: runtime.T(types.Integer),
+				Data:     power,
+			}, nil
+		}
+		base, exp := floatOperands(a, b)
+		if base == 0 && exp < 0 {
+			return runtime.Value{}, errors.New("can not divide by 0")
+		}
+		return runtime.Value{
+			Typeflag: runtime.T(types.Float),
+			Data:     math.Pow(base, exp),
+		}, nil
+	})
+	c.Namespace.Store(runtime.Operator{
+		Symbol:   "^",
+		Function: runtime.NewFunction(nil, numericSignatures(adapter)...),
+		Constant: true,
+	})
+}
+
+// loadFloorDivision loads the floor division operator, rounding the quotient towards negative infinity.
+func loadFloorDivision(c *runtime.Context) {
+	adapter := nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+		var (
+			identA, _ = c.Namespace.Find(runtime.SearchIdentifier, "a")
+			identB, _ = c.Namespace.Find(runtime.SearchIdentifier, "b")
+			a         = identA.(runtime.Value)
+			b         = identB.(runtime.Value)
+		)
+		if a.Type == types.Integer && b.Type == types.Integer {
+			if b.Data.(int64) == 0 {
+				return runtime.Value{}, errors.New("can not divide by 0")
+			}
+			quotient, err := floorDivInt(c, a.Data.(int64), b.Data.(int64))
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			return runtime.Value{
+				Typeflag: runtime.T(types.Integer),
+				Data:     quotient,
+			}, nil
+		}
+		af, bf := floatOperands(a, b)
+		if bf == 0 {
+			return runtime.Value{}, errors.New("can not divide by 0")
+		}
+		return runtime.Value{
+			Typeflag: runtime.T(types.Float),
+			Data:     math.Floor(af / bf),
+		}, nil
+	})
+	c.Namespace.Store(runtime.Operator{
+		Symbol:   "//",
+		Function: runtime.NewFunction(nil, numericSignatures(adapter)...),
+		Constant: true,
+	})
+}
+
 // LoadBasicMath loads basic math operators like addition and subtraction into the runtime context.
 func LoadBasicMath(c *runtime.Context) {
 	loadAddition(c)
@@ -627,4 +752,6 @@ func LoadBasicMath(c *runtime.Context) {
 	loadMultiplication(c)
 	loadSubtraction(c)
 	loadRemainder(c)
+	loadPower(c)
+	loadFloorDivision(c)
 }
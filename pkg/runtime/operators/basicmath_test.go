@@ -0,0 +1,67 @@
+package operators
+
+import (
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestPowerFloorDivision(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	value := func(data interface{}) runtime.Value {
+		if i, ok := data.(int); ok {
+			return runtime.Value{Typeflag: runtime.T(types.Integer), Data: int64(i)}
+		}
+		return runtime.Value{Typeflag: runtime.T(types.Float), Data: data}
+	}
+	tests := []struct {
+		symbol string
+		a, b   interface{}
+		want   runtime.Value
+	}{
+		{"^", 2, 10, value(1024)},
+		{"^", 7, 0, value(1)},
+		{"^", 0, 0, value(1)},
+		{"^", -3, 3, value(-27)},
+		{"^", 2, -2, value(0.25)},
+		{"^", 4, 0.5, value(2.0)},
+		{"^", 2.5, 2, value(6.25)},
+		{"^", 2.0, -1.0, value(0.5)},
+		{"//", 7, 2, value(3)},
+		{"//", -7, 2, value(-4)},
+		{"//", 7, -2, value(-4)},
+		{"//", -7, -2, value(3)},
+		{"//", -8, 2, value(-4)},
+		{"//", 7.5, 2, value(3.0)},
+		{"//", -7, 2.0, value(-4.0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			item, err := c.Namespace.Find(runtime.SearchOperator, tt.symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := item.(runtime.Operator).Eval(c, []runtime.Value{value(tt.a), value(tt.b)})
+			if err != nil {
+				t.Fatalf("%v %s %v error = %v", tt.a, tt.symbol, tt.b, err)
+			}
+			if got.Type != tt.want.Type || got.Data != tt.want.Data {
+				t.Errorf("%v %s %v = %s %v, want %s %v", tt.a, tt.symbol, tt.b, got.Typeflag, got, tt.want.Typeflag, tt.want)
+			}
+		})
+	}
+	for _, args := range [][]runtime.Value{{value(0), value(-1)}, {value(0.0), value(-0.5)}} {
+		item, _ := c.Namespace.Find(runtime.SearchOperator, "^")
+		if got, err := item.(runtime.Operator).Eval(c, args); err == nil {
+			t.Errorf("%v ^ %v = %v, want division by zero error", args[0], args[1], got)
+		}
+	}
+	for _, args := range [][]runtime.Value{{value(1), value(0)}, {value(1.0), value(0)}} {
+		item, _ := c.Namespace.Find(runtime.SearchOperator, "//")
+		if got, err := item.(runtime.Operator).Eval(c, args); err == nil {
+			t.Errorf("%v // %v = %v, want division by zero error", args[0], args[1], got)
+		}
+	}
+}
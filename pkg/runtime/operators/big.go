@@ -0,0 +1,178 @@
+package operators
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+var (
+	// errDivisionByZero is returned when dividing an arbitrary-precision number by zero.
+	errDivisionByZero = errors.New("can not divide by 0")
+	// errNullOperand is returned when calculating with a bigint or decimal declared without a value.
+	errNullOperand = errors.New("can not calculate with null")
+)
+
+// promoted constructs the signatures of an operation on the datatype, accepting operands of the datatype
+// and the given narrower datatypes which are promoted to it.
+func promoted(datatype, returns *runtime.Datatype, narrower []*runtime.Datatype, fn func(a, b interface{}) (interface{}, error)) []runtime.Signature {
+	signatures := []runtime.Signature{operation(datatype, datatype, datatype, returns, fn)}
+	for _, other := range narrower {
+		signatures = append(signatures,
+			operation(datatype, other, datatype, returns, fn),
+			operation(other, datatype, datatype, returns, fn),
+		)
+	}
+	return signatures
+}
+
+// unary constructs a signature of an operator with a single operand of the given type.
+func unary(datatype *runtime.Datatype, fn func(a interface{}) interface{}) runtime.Signature {
+	return runtime.Signature{
+		Expected: []runtime.Value{
+			{
+				Name:     "a",
+				Typeflag: runtime.T(datatype),
+				Constant: true,
+			},
+		},
+		Function: nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+			a, _ := c.Namespace.Find(runtime.SearchIdentifier, "a")
+			return runtime.Value{
+				Typeflag: runtime.T(datatype),
+				Data:     fn(a.(runtime.Value).Data),
+			}, nil
+		}),
+		Returns: runtime.Value{Typeflag: runtime.T(datatype)},
+	}
+}
+
+// bigIntOperation adapts an operation on arbitrary-precision integers.
+func bigIntOperation(fn func(z, a, b *big.Int) (*big.Int, error)) func(a, b interface{}) (interface{}, error) {
+	return func(a, b interface{}) (interface{}, error) {
+		x, okA := a.(*big.Int)
+		y, okB := b.(*big.Int)
+		if !okA || !okB {
+			return nil, errNullOperand
+		}
+		return fn(new(big.Int), x, y)
+	}
+}
+
+// decimalOperation adapts an operation on decimals.
+func decimalOperation(fn func(z, a, b *big.Rat) (*big.Rat, error)) func(a, b interface{}) (interface{}, error) {
+	return func(a, b interface{}) (interface{}, error) {
+		x, okA := a.(*types.Rational)
+		y, okB := b.(*types.Rational)
+		if !okA || !okB {
+			return nil, errNullOperand
+		}
+		r, err := fn(new(big.Rat), x.Rat(), y.Rat())
+		return (*types.Rational)(r), err
+	}
+}
+
+// compareBig returns -1, 0 or 1 if the bigint or decimal a is smaller than, equal to or greater than b.
+func compareBig(a, b interface{}) int {
+	if a, ok := a.(*big.Int); ok {
+		return a.Cmp(b.(*big.Int))
+	}
+	return a.(*types.Rational).Rat().Cmp(b.(*types.Rational).Rat())
+}
+
+// LoadBig overloads arithmetic and comparison operators for bigints and decimals.
+// Integers are promoted to bigints and decimals, bigints to decimals.
+func LoadBig(c *runtime.Context) {
+	var (
+		bigIntNarrower  = []*runtime.Datatype{types.Integer}
+		decimalNarrower = []*runtime.Datatype{types.Integer, types.BigInt}
+	)
+	arithmetic := func(symbol string, bigInts func(z, a, b *big.Int) (*big.Int, error), decimals func(z, a, b *big.Rat) (*big.Rat, error)) {
+		var signatures []runtime.Signature
+		if bigInts != nil {
+			signatures = append(signatures, promoted(types.BigInt, types.BigInt, bigIntNarrower, bigIntOperation(bigInts))...)
+		}
+		if decimals != nil {
+			signatures = append(signatures, promoted(types.Decimal, types.Decimal, decimalNarrower, decimalOperation(decimals))...)
+		}
+		overload(c, symbol, signatures...)
+	}
+	arithmetic("+", func(z, a, b *big.Int) (*big.Int, error) {
+		return z.Add(a, b), nil
+	}, func(z, a, b *big.Rat) (*big.Rat, error) {
+		return z.Add(a, b), nil
+	})
+	arithmetic("-", func(z, a, b *big.Int) (*big.Int, error) {
+		return z.Sub(a, b), nil
+	}, func(z, a, b *big.Rat) (*big.Rat, error) {
+		return z.Sub(a, b), nil
+	})
+	arithmetic("*", func(z, a, b *big.Int) (*big.Int, error) {
+		return z.Mul(a, b), nil
+	}, func(z, a, b *big.Rat) (*big.Rat, error) {
+		return z.Mul(a, b), nil
+	})
+	arithmetic("/", func(z, a, b *big.Int) (*big.Int, error) {
+		if b.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return z.Quo(a, b), nil
+	}, func(z, a, b *big.Rat) (*big.Rat, error) {
+		if b.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return z.Quo(a, b), nil
+	})
+	arithmetic("%", func(z, a, b *big.Int) (*big.Int, error) {
+		if b.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return z.Rem(a, b), nil
+	}, nil)
+	overload(c, "-",
+		// the negation of null is null
+		unary(types.BigInt, func(a interface{}) interface{} {
+			if a == nil {
+				return nil
+			}
+			return new(big.Int).Neg(a.(*big.Int))
+		}),
+		unary(types.Decimal, func(a interface{}) interface{} {
+			if a == nil {
+				return nil
+			}
+			return (*types.Rational)(new(big.Rat).Neg(a.(*types.Rational).Rat()))
+		}),
+	)
+
+	orders := map[string]func(order int) bool{
+		"==": func(order int) bool { return order == 0 },
+		"!=": func(order int) bool { return order != 0 },
+	}
+	for symbol, holds := range comparisons {
+		orders[symbol] = holds
+	}
+	for symbol, holds := range orders {
+		symbol, holds := symbol, holds
+		compare := func(a, b interface{}) (interface{}, error) {
+			if a == nil || b == nil {
+				// null numbers are only equal to null and can not be ordered
+				switch symbol {
+				case "==":
+					return a == b, nil
+				case "!=":
+					return a != b, nil
+				}
+				return nil, errNullOperand
+			}
+			return holds(compareBig(a, b)), nil
+		}
+		overload(c, symbol, append(
+			promoted(types.BigInt, types.Bool, bigIntNarrower, compare),
+			promoted(types.Decimal, types.Bool, decimalNarrower, compare)...,
+		)...)
+	}
+}
@@ -0,0 +1,110 @@
+package operators
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestBig(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	var (
+		integer = func(i int64) runtime.Value {
+			return runtime.Value{Typeflag: runtime.T(types.Integer), Data: i}
+		}
+		bigint = func(s string) runtime.Value {
+			i, _ := new(big.Int).SetString(s, 10)
+			return runtime.Value{Typeflag: runtime.T(types.BigInt), Data: i}
+		}
+		decimal = func(s string) runtime.Value {
+			r, _ := new(big.Rat).SetString(s)
+			return types.NewDecimal(r)
+		}
+		// bigints and decimals declared without a value
+		nullBigInt  = runtime.Value{Typeflag: runtime.T(types.BigInt)}
+		nullDecimal = runtime.Value{Typeflag: runtime.T(types.Decimal)}
+	)
+	tests := []struct {
+		symbol string
+		args   []runtime.Value
+		want   string
+	}{
+		{"+", []runtime.Value{bigint("9223372036854775807"), integer(1)}, "9223372036854775808"},
+		{"*", []runtime.Value{bigint("-9223372036854775808"), bigint("2")}, "-18446744073709551616"},
+		{"/", []runtime.Value{integer(-7), bigint("2")}, "-3"},
+		{"%", []runtime.Value{bigint("-7"), integer(2)}, "-1"},
+		{"-", []runtime.Value{bigint("5")}, "-5"},
+		{"+", []runtime.Value{decimal("0.1"), decimal("0.2")}, "0.3"},
+		{"-", []runtime.Value{decimal("10.25"), bigint("3")}, "7.25"},
+		{"*", []runtime.Value{integer(3), decimal("19.99")}, "59.97"},
+		{"/", []runtime.Value{decimal("1"), integer(8)}, "0.125"},
+		{"/", []runtime.Value{decimal("2"), decimal("3")}, "0.66666666666666666667"},
+		{"-", []runtime.Value{decimal("0.5")}, "-0.5"},
+		{"==", []runtime.Value{decimal("0.30"), decimal("0.3")}, "true"},
+		{"!=", []runtime.Value{bigint("1"), bigint("1")}, "false"},
+		{"<", []runtime.Value{integer(2), bigint("3")}, "true"},
+		{">=", []runtime.Value{decimal("1.5"), bigint("2")}, "false"},
+		{"-", []runtime.Value{nullBigInt}, "null"},
+		{"==", []runtime.Value{nullDecimal, nullDecimal}, "true"},
+		{"!=", []runtime.Value{nullBigInt, integer(0)}, "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			item, err := c.Namespace.Find(runtime.SearchOperator, tt.symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := item.(runtime.Operator).Eval(c, tt.args)
+			if err != nil {
+				t.Fatalf("%s%v error = %v", tt.symbol, tt.args, err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("%s%v = %v, want %s", tt.symbol, tt.args, got, tt.want)
+			}
+		})
+	}
+	item, _ := c.Namespace.Find(runtime.SearchOperator, "/")
+	if _, err := item.(runtime.Operator).Eval(c, []runtime.Value{decimal("1"), decimal("0")}); err == nil {
+		t.Error("decimal division by zero expected error")
+	}
+	for _, symbol := range []string{"+", "<"} {
+		item, _ := c.Namespace.Find(runtime.SearchOperator, symbol)
+		if _, err := item.(runtime.Operator).Eval(c, []runtime.Value{nullBigInt, bigint("1")}); err == nil {
+			t.Errorf("null bigint %s 1n expected error", symbol)
+		}
+	}
+	casts := []struct {
+		value    runtime.Value
+		datatype *runtime.Datatype
+		want     string
+	}{
+		{runtime.Value{Typeflag: runtime.T(types.Float), Data: 0.1}, types.Decimal, "0.1"},
+		{runtime.Value{Typeflag: runtime.T(types.Float), Data: -2.9}, types.BigInt, "-2"},
+		{decimal("-7.5"), types.BigInt, "-7"},
+		{decimal("-7.5"), types.Integer, "-7"},
+		{decimal("0.25"), types.Float, "0.25"},
+		{bigint("12"), types.String, "12"},
+		{runtime.Value{Typeflag: runtime.T(types.String), Data: "1e3"}, types.Decimal, "1000"},
+		{runtime.Value{}, types.BigInt, "null"},
+		{runtime.Value{}, types.Decimal, "null"},
+		{nullBigInt, types.Decimal, "null"},
+		{nullDecimal, types.BigInt, "null"},
+		{nullDecimal, types.Integer, "0"},
+		{nullBigInt, types.Float, "0"},
+	}
+	for _, tt := range casts {
+		got, err := tt.datatype.Cast(tt.value, nil)
+		if err != nil || got.String() != tt.want || got.Type != tt.datatype {
+			t.Errorf("cast %v to %s = %v, %v, want %s", tt.value, tt.datatype, got, err, tt.want)
+		}
+	}
+	if _, err := types.Integer.Cast(bigint("9223372036854775808"), nil); err == nil {
+		t.Error("cast of overflowing bigint to int expected error")
+	}
+	if _, err := types.Decimal.Cast(runtime.Value{Typeflag: runtime.T(types.String), Data: "1/3"}, nil); err == nil {
+		t.Error("cast of fraction string to decimal expected error")
+	}
+}
@@ -0,0 +1,62 @@
+package operators
+
+import (
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// shift constructs the signature of a shift operator, negative shift counts are rejected.
+func shift(fn func(c *runtime.Context, a, n int64) (int64, error)) runtime.Signature {
+	adapter := nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+		var (
+			identA, _ = c.Namespace.Find(runtime.SearchIdentifier, "a")
+			identB, _ = c.Namespace.Find(runtime.SearchIdentifier, "b")
+			n         = identB.(runtime.Value).Data.(int64)
+		)
+		if n < 0 {
+			return runtime.Value{}, errors.Errorf("can not shift by negative count %d", n)
+		}
+		result, err := fn(c, identA.(runtime.Value).Data.(int64), n)
+		if err != nil {
+			return runtime.Value{}, err
+		}
+		return runtime.Value{
+			Typeflag: runtime.T(types.Integer),
+			Data:     result,
+		}, nil
+	})
+	return runtime.NewSignature(runtime.Value{Typeflag: runtime.T(types.Integer)}, adapter, []runtime.Value{
+		{
+			Name:     "a",
+			Typeflag: runtime.T(types.Integer),
+			Constant: true,
+		},
+		{
+			Name:     "b",
+			Typeflag: runtime.T(types.Integer),
+			Constant: true,
+		},
+	})
+}
+
+// LoadBitwise loads the bitwise and shift operators on integers.
+// Left shifts are checked for overflows like multiplications, right shifts are arithmetic.
+func LoadBitwise(c *runtime.Context) {
+	bitwise := func(symbol string, fn func(a, b int64) int64) {
+		overload(c, symbol, binary(types.Integer, types.Integer, types.Integer, func(a, b interface{}) interface{} {
+			return fn(a.(int64), b.(int64))
+		}))
+	}
+	bitwise("&", func(a, b int64) int64 { return a & b })
+	bitwise("|", func(a, b int64) int64 { return a | b })
+	bitwise("xor", func(a, b int64) int64 { return a ^ b })
+	overload(c, "~", unary(types.Integer, func(a interface{}) interface{} {
+		return ^a.(int64)
+	}))
+	overload(c, "<<", shift(shlInt))
+	overload(c, ">>", shift(func(c *runtime.Context, a, n int64) (int64, error) {
+		return a >> uint64(n), nil
+	}))
+}
@@ -0,0 +1,58 @@
+package operators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestBitwise(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	tests := []struct {
+		symbol string
+		args   []int64
+		want   int64
+		fails  bool
+	}{
+		{"&", []int64{12, 10}, 8, false},
+		{"|", []int64{12, 10}, 14, false},
+		{"xor", []int64{12, -10}, -6, false},
+		{"~", []int64{0}, -1, false},
+		{"~", []int64{math.MinInt64}, math.MaxInt64, false},
+		{"<<", []int64{1, 62}, 1 << 62, false},
+		{"<<", []int64{-1, 63}, math.MinInt64, false},
+		{"<<", []int64{1, 63}, 0, true},
+		{"<<", []int64{3, 64}, 0, true},
+		{"<<", []int64{0, 100}, 0, false},
+		{"<<", []int64{1, -1}, 0, true},
+		{">>", []int64{-16, 2}, -4, false},
+		{">>", []int64{math.MaxInt64, 64}, 0, false},
+		{">>", []int64{math.MinInt64, 100}, -1, false},
+		{">>", []int64{1, -1}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			args := make([]runtime.Value, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = runtime.Value{Typeflag: runtime.T(types.Integer), Data: arg}
+			}
+			item, err := c.Namespace.Find(runtime.SearchOperator, tt.symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := item.(runtime.Operator).Eval(c, args)
+			if tt.fails {
+				if err == nil {
+					t.Errorf("%s%v = %v, want error", tt.symbol, tt.args, got)
+				}
+				return
+			}
+			if err != nil || got.Data != tt.want {
+				t.Errorf("%s%v = %v, %v, want %d", tt.symbol, tt.args, got, err, tt.want)
+			}
+		})
+	}
+}
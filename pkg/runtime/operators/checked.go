@@ -0,0 +1,118 @@
+package operators
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tealang/core/pkg/runtime"
+)
+
+// overflow handles an integer operation exceeding the range of int according to the mode of the context.
+// The wrapped result is computed by Go's two's complement arithmetic, positive tells the direction to saturate in.
+func overflow(c *runtime.Context, operation string, wrapped int64, positive bool) (int64, error) {
+	switch c.Overflow {
+	case runtime.OverflowWrap:
+		return wrapped, nil
+	case runtime.OverflowSaturate:
+		if positive {
+			return math.MaxInt64, nil
+		}
+		return math.MinInt64, nil
+	}
+	return 0, &runtime.OverflowError{Operation: operation}
+}
+
+// addInt adds the integers, detecting overflows.
+func addInt(c *runtime.Context, a, b int64) (int64, error) {
+	sum := a + b
+	if (a > 0 && b > 0 && sum < 0) || (a < 0 && b < 0 && sum >= 0) {
+		return overflow(c, fmt.Sprintf("%d + %d", a, b), sum, a > 0)
+	}
+	return sum, nil
+}
+
+// subInt subtracts the integers, detecting overflows.
+func subInt(c *runtime.Context, a, b int64) (int64, error) {
+	diff := a - b
+	if (a >= 0 && b < 0 && diff < 0) || (a < 0 && b > 0 && diff >= 0) {
+		return overflow(c, fmt.Sprintf("%d - %d", a, b), diff, a >= 0)
+	}
+	return diff, nil
+}
+
+// multiplicationOverflows reports whether the product of the integers exceeds the range of int.
+func multiplicationOverflows(a, b int64) bool {
+	return a != 0 && ((a*b)/a != b || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64))
+}
+
+// mulInt multiplies the integers, detecting overflows.
+func mulInt(c *runtime.Context, a, b int64) (int64, error) {
+	if multiplicationOverflows(a, b) {
+		return overflow(c, fmt.Sprintf("%d * %d", a, b), a*b, (a < 0) == (b < 0))
+	}
+	return a * b, nil
+}
+
+// divInt divides the integers truncating towards zero, detecting the overflow of the smallest int divided by -1.
+// The divisor must not be zero.
+func divInt(c *runtime.Context, a, b int64) (int64, error) {
+	if a == math.MinInt64 && b == -1 {
+		return overflow(c, fmt.Sprintf("%d / %d", a, b), a, true)
+	}
+	return a / b, nil
+}
+
+// floorDivInt divides the integers rounding towards negative infinity, detecting overflows like divInt.
+// The divisor must not be zero.
+func floorDivInt(c *runtime.Context, a, b int64) (int64, error) {
+	quotient, err := divInt(c, a, b)
+	if err != nil {
+		return 0, err
+	}
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		quotient--
+	}
+	return quotient, nil
+}
+
+// powInt raises the integer to the non-negative power by repeated squaring, detecting overflows.
+// The wrapped result is the exact power modulo 2^64, since the squared base is part of the result
+// as long as exponent bits remain.
+func powInt(c *runtime.Context, a, n int64) (int64, error) {
+	var (
+		result, base = int64(1), a
+		overflows    bool
+	)
+	for exp := n; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			overflows = overflows || multiplicationOverflows(result, base)
+			result *= base
+		}
+		if exp > 1 {
+			overflows = overflows || multiplicationOverflows(base, base)
+			base *= base
+		}
+	}
+	if overflows {
+		return overflow(c, fmt.Sprintf("%d ^ %d", a, n), result, a > 0 || n%2 == 0)
+	}
+	return result, nil
+}
+
+// negInt negates the integer, detecting the overflow of the smallest int.
+func negInt(c *runtime.Context, a int64) (int64, error) {
+	if a == math.MinInt64 {
+		return overflow(c, fmt.Sprintf("-(%d)", a), a, true)
+	}
+	return -a, nil
+}
+
+// shlInt shifts the integer to the left, detecting overflows of the shifted bits.
+// The shift count must not be negative.
+func shlInt(c *runtime.Context, a, n int64) (int64, error) {
+	shifted := a << uint64(n)
+	if a != 0 && (n >= 64 || shifted>>uint64(n) != a) {
+		return overflow(c, fmt.Sprintf("%d << %d", a, n), shifted, a > 0)
+	}
+	return shifted, nil
+}
@@ -0,0 +1,87 @@
+package operators
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestIntegerOverflow(t *testing.T) {
+	const (
+		max = math.MaxInt64
+		min = math.MinInt64
+	)
+	c := runtime.NewContext()
+	Load(c)
+	// results of the wrap and saturate modes, the check mode fails if they differ from each other
+	tests := []struct {
+		symbol         string
+		args           []int64
+		wrap, saturate int64
+	}{
+		{"+", []int64{max, 0}, max, max},
+		{"+", []int64{max, 1}, min, max},
+		{"+", []int64{min, -1}, max, min},
+		{"+", []int64{max, min}, -1, -1},
+		{"+", []int64{min, min}, 0, min},
+		{"-", []int64{min, 0}, min, min},
+		{"-", []int64{min, 1}, max, min},
+		{"-", []int64{max, -1}, min, max},
+		{"-", []int64{0, min}, min, max},
+		{"-", []int64{-1, min}, max, max},
+		{"*", []int64{max, 1}, max, max},
+		{"*", []int64{max, 2}, -2, max},
+		{"*", []int64{min, -1}, min, max},
+		{"*", []int64{-1, min}, min, max},
+		{"*", []int64{min, 2}, 0, min},
+		{"*", []int64{max, -1}, -max, -max},
+		{"*", []int64{1 << 32, 1 << 31}, min, max},
+		{"/", []int64{min, -1}, min, max},
+		{"/", []int64{min, 1}, min, min},
+		{"/", []int64{max, -1}, -max, -max},
+		{"%", []int64{min, -1}, 0, 0},
+		{"%", []int64{max, min}, max, max},
+		{"//", []int64{min, -1}, min, max},
+		{"//", []int64{min, 2}, min / 2, min / 2},
+		{"^", []int64{2, 62}, 1 << 62, 1 << 62},
+		{"^", []int64{2, 63}, min, max},
+		{"^", []int64{-2, 63}, min, min},
+		{"^", []int64{-2, 64}, 0, max},
+		{"^", []int64{-1, max}, -1, -1},
+		{"^", []int64{min, 1}, min, min},
+		{"-", []int64{min}, min, max},
+		{"-", []int64{max}, -max, -max},
+	}
+	modes := []runtime.OverflowMode{runtime.OverflowCheck, runtime.OverflowWrap, runtime.OverflowSaturate}
+	for _, tt := range tests {
+		args := make([]runtime.Value, len(tt.args))
+		for i, arg := range tt.args {
+			args[i] = runtime.Value{Typeflag: runtime.T(types.Integer), Data: arg}
+		}
+		item, err := c.Namespace.Find(runtime.SearchOperator, tt.symbol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, mode := range modes {
+			t.Run(tt.symbol+" "+mode.String(), func(t *testing.T) {
+				c.Overflow = mode
+				got, err := item.(runtime.Operator).Eval(c, args)
+				overflows := tt.wrap != tt.saturate
+				switch {
+				case mode == runtime.OverflowCheck && overflows:
+					if _, ok := errors.Cause(err).(*runtime.OverflowError); !ok {
+						t.Errorf("%s%v = %v, %v, want overflow error", tt.symbol, tt.args, got, err)
+					}
+				case err != nil:
+					t.Errorf("%s%v error = %v", tt.symbol, tt.args, err)
+				case mode == runtime.OverflowSaturate && got.Data != tt.saturate,
+					mode != runtime.OverflowSaturate && got.Data != tt.wrap:
+					t.Errorf("%s%v = %v, want %d wrapped or %d saturated", tt.symbol, tt.args, got, tt.wrap, tt.saturate)
+				}
+			})
+		}
+	}
+}
@@ -2,6 +2,11 @@
 package operators
 
 import (
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
 	"github.com/tealang/core/pkg/runtime/nodes"
 	"github.com/tealang/core/pkg/runtime/types"
@@ -28,9 +33,13 @@ func loadUnequals(c *runtime.Context) {
 				a         = identA.(runtime.Value)
 				b         = identB.(runtime.Value)
 			)
+			equal, err := equalData(a.Data, b.Data)
+			if err != nil {
+				return runtime.Value{}, err
+			}
 			return runtime.Value{
 				Typeflag: runtime.T(types.Bool),
-				Data:     a.Data != b.Data,
+				Data:     !equal,
 			}, nil
 		}),
 		Returns: runtime.Value{Typeflag: runtime.T(types.Bool)},
@@ -70,9 +79,13 @@ func loadEquals(c *runtime.Context) {
 				a         = identA.(runtime.Value)
 				b         = identB.(runtime.Value)
 			)
+			equal, err := equalData(a.Data, b.Data)
+			if err != nil {
+				return runtime.Value{}, err
+			}
 			return runtime.Value{
 				Typeflag: runtime.T(types.Bool),
-				Data:     a.Data == b.Data,
+				Data:     equal,
 			}, nil
 		}),
 		Returns: runtime.Value{Typeflag: runtime.T(types.Bool)},
@@ -90,6 +103,55 @@ func loadEquals(c *runtime.Context) {
 	}
 	c.Namespace.Store(equals)
 }
+
+// equalData compares the data of two values, arrays and maps are compared element by element.
+// Data that can not be compared, e.g. functions, results in an error.
+func equalData(a, b interface{}) (bool, error) {
+	switch a := a.(type) {
+	case []runtime.Value:
+		b, ok := b.([]runtime.Value)
+		if !ok || len(a) != len(b) {
+			return false, nil
+		}
+		for i := range a {
+			if equal, err := equalData(a[i].Data, b[i].Data); !equal || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	case map[string]runtime.Value:
+		b, ok := b.(map[string]runtime.Value)
+		if !ok || len(a) != len(b) {
+			return false, nil
+		}
+		for key, item := range a {
+			other, ok := b[key]
+			if !ok {
+				return false, nil
+			}
+			if equal, err := equalData(item.Data, other.Data); !equal || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	case time.Time:
+		b, ok := b.(time.Time)
+		return ok && a.Equal(b), nil
+	case *big.Int:
+		b, ok := b.(*big.Int)
+		return ok && a.Cmp(b) == 0, nil
+	case *types.Rational:
+		b, ok := b.(*types.Rational)
+		return ok && a.Rat().Cmp(b.Rat()) == 0, nil
+	}
+	for _, data := range []interface{}{a, b} {
+		if data != nil && !reflect.TypeOf(data).Comparable() {
+			return false, errors.Errorf("can not compare values of type %T", data)
+		}
+	}
+	return a == b, nil
+}
+
 func loadNegation(c *runtime.Context) {
 	negBool := runtime.Signature{
 		Expected: []runtime.Value{
@@ -202,9 +264,10 @@ func loadLogicalOr(c *runtime.Context) {
 		Source: nil,
 	}
 	or := runtime.Operator{
-		Function: orFunction,
-		Symbol:   "||",
-		Constant: true,
+		Function:     orFunction,
+		Symbol:       "||",
+		Constant:     true,
+		ShortCircuit: shortCircuit(true),
 	}
 	c.Namespace.Store(or)
 }
@@ -244,13 +307,73 @@ func loadLogicalAnd(c *runtime.Context) {
 		Source: nil,
 	}
 	and := runtime.Operator{
-		Function: andFunction,
-		Symbol:   "&&",
-		Constant: true,
+		Function:     andFunction,
+		Symbol:       "&&",
+		Constant:     true,
+		ShortCircuit: shortCircuit(false),
 	}
 	c.Namespace.Store(and)
 }
 
+// shortCircuit skips the second operand of a logical operator if the first one is the given result.
+func shortCircuit(result bool) func(a runtime.Value) (runtime.Value, bool) {
+	return func(a runtime.Value) (runtime.Value, bool) {
+		if a.Type != types.Bool || a.Data != result {
+			return runtime.Value{}, false
+		}
+		return runtime.Value{
+			Typeflag: runtime.T(types.Bool),
+			Data:     result,
+		}, true
+	}
+}
+
+// loadNullCoalescing loads the ?? operator resulting in the first operand unless it is null.
+// The second operand is only evaluated if the first one is null.
+func loadNullCoalescing(c *runtime.Context) {
+	coalesceAny := runtime.Signature{
+		Expected: []runtime.Value{
+			{
+				Name:     "a",
+				Typeflag: runtime.T(types.Any),
+				Constant: true,
+			},
+			{
+				Name:     "b",
+				Typeflag: runtime.T(types.Any),
+				Constant: true,
+			},
+		},
+		// the operation short-circuits on non-null first operands, so the result is the second one
+		Function: nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+			identB, _ := c.Namespace.Find(runtime.SearchIdentifier, "b")
+			return coalesced(identB.(runtime.Value)), nil
+		}),
+	}
+	coalesce := runtime.Operator{
+		Function: runtime.Function{
+			Signatures: []runtime.Signature{
+				coalesceAny,
+			},
+			Source: nil,
+		},
+		Symbol:   "??",
+		Constant: true,
+		ShortCircuit: func(a runtime.Value) (runtime.Value, bool) {
+			return coalesced(a), a.Data != nil
+		},
+	}
+	c.Namespace.Store(coalesce)
+}
+
+// coalesced normalizes an operand of ?? to an unnamed, unwrapped value, null operands result in null.
+func coalesced(v runtime.Value) runtime.Value {
+	if v.Data == nil {
+		return runtime.Value{}
+	}
+	return runtime.Value{Typeflag: types.Underlying(v).Typeflag, Data: v.Data}
+}
+
 // LoadLogical loads basic boolean logic operators into the runtime context.
 func LoadLogical(c *runtime.Context) {
 	loadNegation(c)
@@ -259,4 +382,5 @@ func LoadLogical(c *runtime.Context) {
 	loadLogicalXor(c)
 	loadEquals(c)
 	loadUnequals(c)
+	loadNullCoalescing(c)
 }
@@ -0,0 +1,62 @@
+package operators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestEquals(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	var (
+		integer = func(i int64) runtime.Value { return runtime.Value{Typeflag: runtime.T(types.Integer), Data: i} }
+		array   = func(items ...runtime.Value) runtime.Value {
+			return runtime.Value{Typeflag: runtime.T(types.Array, types.Any), Data: items}
+		}
+		object = func(key string, item runtime.Value) runtime.Value {
+			return runtime.Value{Typeflag: runtime.T(types.Map, types.Any), Data: map[string]runtime.Value{key: item}}
+		}
+		function = runtime.Value{Typeflag: runtime.T(types.Function), Data: runtime.NewFunction(nil)}
+		instant  = time.Date(2018, 3, 4, 12, 0, 0, 0, time.UTC)
+		times    = func(t time.Time) runtime.Value {
+			return array(runtime.Value{Typeflag: runtime.T(types.Time), Data: t})
+		}
+	)
+	tests := []struct {
+		name    string
+		a, b    runtime.Value
+		want    bool
+		wantErr bool
+	}{
+		{"Integers", integer(1), integer(1), true, false},
+		{"Equal arrays", array(integer(1), integer(2)), array(integer(1), integer(2)), true, false},
+		{"Different arrays", array(integer(1), integer(2)), array(integer(1), integer(3)), false, false},
+		{"Different lengths", array(integer(1)), array(integer(1), integer(2)), false, false},
+		{"Nested maps", object("a", array(integer(1))), object("a", array(integer(1))), true, false},
+		{"Different keys", object("a", integer(1)), object("b", integer(1)), false, false},
+		{"Array and integer", array(integer(1)), integer(1), false, false},
+		{"Null", array(), runtime.Value{}, false, false},
+		{"Functions", function, function, false, true},
+		{"Arrays of zoned times", times(instant), times(instant.In(time.FixedZone("CET", 3600))), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for symbol, want := range map[string]bool{"==": tt.want, "!=": !tt.want} {
+				item, err := c.Namespace.Find(runtime.SearchOperator, symbol)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := item.(runtime.Operator).Eval(c, []runtime.Value{tt.a, tt.b})
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("%v %s %v error = %v, wantErr %v", tt.a, symbol, tt.b, err, tt.wantErr)
+				}
+				if err == nil && got.Data != want {
+					t.Errorf("%v %s %v = %v, want %v", tt.a, symbol, tt.b, got, want)
+				}
+			}
+		})
+	}
+}
@@ -8,4 +8,7 @@ func Load(c *runtime.Context) {
 	LoadBasicMath(c)
 	LoadLogical(c)
 	LoadCompare(c)
+	LoadTime(c)
+	LoadBig(c)
+	LoadBitwise(c)
 }
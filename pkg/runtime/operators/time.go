@@ -0,0 +1,146 @@
+package operators
+
+import (
+	"time"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// binary constructs a signature of an operator with two operands of the given types.
+func binary(a, b, returns *runtime.Datatype, fn func(a, b interface{}) interface{}) runtime.Signature {
+	return operation(a, b, nil, returns, func(a, b interface{}) (interface{}, error) {
+		return fn(a, b), nil
+	})
+}
+
+// operation constructs a signature of an operator with two operands of the given types,
+// both operands are cast to the operand datatype before applying the failable operation unless it is nil.
+func operation(a, b, operand, returns *runtime.Datatype, fn func(a, b interface{}) (interface{}, error)) runtime.Signature {
+	return runtime.Signature{
+		Expected: []runtime.Value{
+			{
+				Name:     "a",
+				Typeflag: runtime.T(a),
+				Constant: true,
+			},
+			{
+				Name:     "b",
+				Typeflag: runtime.T(b),
+				Constant: true,
+			},
+		},
+		Function: nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+			var (
+				identA, _ = c.Namespace.Find(runtime.SearchIdentifier, "a")
+				identB, _ = c.Namespace.Find(runtime.SearchIdentifier, "b")
+				a         = identA.(runtime.Value)
+				b         = identB.(runtime.Value)
+				err       error
+			)
+			if operand != nil {
+				if a, err = operand.Cast(a, nil); err != nil {
+					return runtime.Value{}, err
+				}
+				if b, err = operand.Cast(b, nil); err != nil {
+					return runtime.Value{}, err
+				}
+			}
+			result, err := fn(a.Data, b.Data)
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			return runtime.Value{
+				Typeflag: runtime.T(returns),
+				Data:     result,
+			}, nil
+		}),
+		Returns: runtime.Value{Typeflag: runtime.T(returns)},
+	}
+}
+
+// overload adds the signatures to the operator of the symbol.
+func overload(c *runtime.Context, symbol string, signatures ...runtime.Signature) {
+	c.Namespace.Overload(runtime.Operator{
+		Function: runtime.Function{
+			Signatures: signatures,
+			Source:     nil,
+		},
+		Symbol:   symbol,
+		Constant: true,
+	})
+}
+
+// comparisons map the comparison operators to the condition on the order of their operands.
+var comparisons = map[string]func(order int) bool{
+	"<":  func(order int) bool { return order < 0 },
+	"<=": func(order int) bool { return order <= 0 },
+	">":  func(order int) bool { return order > 0 },
+	">=": func(order int) bool { return order >= 0 },
+}
+
+// compareTimes returns -1, 0 or 1 if the time or duration a is before, equal to or after b.
+func compareTimes(a, b interface{}) int {
+	switch a := a.(type) {
+	case time.Time:
+		switch {
+		case a.Before(b.(time.Time)):
+			return -1
+		case a.After(b.(time.Time)):
+			return 1
+		}
+	case time.Duration:
+		switch {
+		case a < b.(time.Duration):
+			return -1
+		case a > b.(time.Duration):
+			return 1
+		}
+	}
+	return 0
+}
+
+// LoadTime overloads arithmetic and comparison operators for times and durations.
+func LoadTime(c *runtime.Context) {
+	overload(c, "+",
+		binary(types.Time, types.Duration, types.Time, func(a, b interface{}) interface{} {
+			return a.(time.Time).Add(b.(time.Duration))
+		}),
+		binary(types.Duration, types.Time, types.Time, func(a, b interface{}) interface{} {
+			return b.(time.Time).Add(a.(time.Duration))
+		}),
+		binary(types.Duration, types.Duration, types.Duration, func(a, b interface{}) interface{} {
+			return a.(time.Duration) + b.(time.Duration)
+		}),
+	)
+	overload(c, "-",
+		binary(types.Time, types.Duration, types.Time, func(a, b interface{}) interface{} {
+			return a.(time.Time).Add(-b.(time.Duration))
+		}),
+		binary(types.Time, types.Time, types.Duration, func(a, b interface{}) interface{} {
+			return a.(time.Time).Sub(b.(time.Time))
+		}),
+		binary(types.Duration, types.Duration, types.Duration, func(a, b interface{}) interface{} {
+			return a.(time.Duration) - b.(time.Duration)
+		}),
+	)
+	// equal times are the same instant regardless of their location and monotonic clock reading
+	orders := map[string]func(order int) bool{
+		"==": func(order int) bool { return order == 0 },
+		"!=": func(order int) bool { return order != 0 },
+	}
+	for symbol, holds := range comparisons {
+		orders[symbol] = holds
+	}
+	for symbol, holds := range orders {
+		holds := holds
+		compare := func(a, b interface{}) interface{} {
+			return holds(compareTimes(a, b))
+		}
+		overload(c, symbol,
+			binary(types.Time, types.Time, types.Bool, compare),
+			binary(types.Duration, types.Duration, types.Bool, compare),
+		)
+	}
+}
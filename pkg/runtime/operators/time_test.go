@@ -0,0 +1,60 @@
+package operators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+func TestTime(t *testing.T) {
+	c := runtime.NewContext()
+	Load(c)
+	var (
+		start = time.Date(2018, 3, 4, 12, 0, 0, 0, time.UTC)
+		a     = runtime.Value{Typeflag: runtime.T(types.Time), Data: start}
+		b     = runtime.Value{Typeflag: runtime.T(types.Time), Data: start.Add(time.Hour)}
+		hour  = runtime.Value{Typeflag: runtime.T(types.Duration), Data: time.Hour}
+		// the same instant as a in another location
+		zoned = runtime.Value{Typeflag: runtime.T(types.Time), Data: start.In(time.FixedZone("CET", 3600))}
+		// a reading of the monotonic clock, which is stripped by rounding
+		now = runtime.Value{Typeflag: runtime.T(types.Time), Data: time.Now()}
+	)
+	tests := []struct {
+		symbol string
+		a, b   runtime.Value
+		want   interface{}
+	}{
+		{"+", a, hour, start.Add(time.Hour)},
+		{"+", hour, a, start.Add(time.Hour)},
+		{"+", hour, hour, 2 * time.Hour},
+		{"-", b, hour, start},
+		{"-", b, a, time.Hour},
+		{"-", hour, hour, time.Duration(0)},
+		{"<", a, b, true},
+		{">=", a, b, false},
+		{">", hour, runtime.Value{Typeflag: runtime.T(types.Duration), Data: time.Minute}, true},
+		{"==", a, zoned, true},
+		{"!=", a, zoned, false},
+		{"==", a, b, false},
+		{"==", now, runtime.Value{Typeflag: runtime.T(types.Time), Data: now.Data.(time.Time).Round(0)}, true},
+		{"==", hour, runtime.Value{Typeflag: runtime.T(types.Duration), Data: 60 * time.Minute}, true},
+		{"!=", hour, hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			item, err := c.Namespace.Find(runtime.SearchOperator, tt.symbol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := item.(runtime.Operator).Eval(c, []runtime.Value{tt.a, tt.b})
+			if err != nil {
+				t.Fatalf("%v %s %v error = %v", tt.a, tt.symbol, tt.b, err)
+			}
+			if got.Data != tt.want {
+				t.Errorf("%v %s %v = %v, want %v", tt.a, tt.symbol, tt.b, got, tt.want)
+			}
+		})
+	}
+}
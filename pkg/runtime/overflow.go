@@ -0,0 +1,55 @@
+package runtime
+
+import (
+	"github.com/pkg/errors"
+)
+
+// OverflowMode controls the result of integer operations exceeding the range of int.
+type OverflowMode int
+
+const (
+	// OverflowCheck fails the operation with an OverflowError, it is the default.
+	OverflowCheck OverflowMode = iota
+	// OverflowWrap wraps the result around using two's complement arithmetic.
+	OverflowWrap
+	// OverflowSaturate clamps the result to the smallest or greatest int.
+	OverflowSaturate
+)
+
+var overflowModes = []string{"check", "wrap", "saturate"}
+
+func (m OverflowMode) String() string {
+	if int(m) < len(overflowModes) {
+		return overflowModes[m]
+	}
+	return "unknown"
+}
+
+// ParseOverflowMode looks up the overflow mode by its name.
+func ParseOverflowMode(name string) (OverflowMode, error) {
+	for i, mode := range overflowModes {
+		if mode == name {
+			return OverflowMode(i), nil
+		}
+	}
+	return OverflowCheck, errors.Errorf("unknown overflow mode %s", name)
+}
+
+// Set changes the mode to the named one, so modes can be used as command-line flag values.
+func (m *OverflowMode) Set(name string) error {
+	mode, err := ParseOverflowMode(name)
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// OverflowError is returned by integer operations exceeding the range of int in the check mode.
+type OverflowError struct {
+	Operation string
+}
+
+func (e *OverflowError) Error() string {
+	return "integer overflow in " + e.Operation
+}
@@ -0,0 +1,298 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrDenied is returned by builtins accessing a capability the system does not grant.
+var ErrDenied = errors.New("permission denied by sandbox")
+
+// System provides the capabilities builtins use to interact with the outside world.
+// Builtins never access the operating system directly, hosts provide a system to redirect or restrict them.
+type System interface {
+	// Stdin returns the standard input, reading it line by line must not lose buffered data between calls.
+	Stdin() io.Reader
+	Stdout() io.Writer
+	Stderr() io.Writer
+	// Files returns the file system, nil if file access is denied.
+	Files() FileSystem
+	// Getenv looks up the environment variable.
+	Getenv(key string) (string, bool)
+	// Setenv changes the environment variable, it may fail with ErrDenied.
+	Setenv(key, value string) error
+	// Now returns the current time.
+	Now() time.Time
+	// Exec runs the command to completion and returns its exit status, it may fail with ErrDenied.
+	// The command is killed when the Go context is done.
+	Exec(ctx context.Context, name string, args []string, stdout, stderr io.Writer) (int, error)
+}
+
+// FileSystem provides access to files using slash separated names.
+type FileSystem interface {
+	OpenFile(name string, flag int, perm os.FileMode) (io.ReadWriteCloser, error)
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.FileInfo, error)
+	MkdirAll(name string, perm os.FileMode) error
+	Remove(name string) error
+}
+
+// Dir is a file system restricted to the directory tree rooted at the path.
+// Names are resolved relative to the root and can not leave it using '..' elements.
+// Symbolic links are resolved as well, access fails with ErrDenied if a link points outside of the tree.
+// The empty Dir grants unrestricted access to the host.
+type Dir string
+
+// resolve maps the name to a path on the host, following all symbolic links.
+// Missing elements at the end of the name are kept as they are, so files can be created.
+func (d Dir) resolve(name string) (string, error) {
+	if d == "" {
+		return filepath.FromSlash(name), nil
+	}
+	root, err := filepath.EvalSymlinks(string(d))
+	if err != nil {
+		return "", err
+	}
+	existing := filepath.Join(root, filepath.FromSlash(path.Clean("/"+name)))
+	missing := ""
+	for existing != root {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		existing, missing = filepath.Dir(existing), filepath.Join(filepath.Base(existing), missing)
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	resolved = filepath.Join(resolved, missing)
+	if rel, err := filepath.Rel(root, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &os.PathError{Op: "resolve", Path: name, Err: ErrDenied}
+	}
+	return resolved, nil
+}
+
+// resolveLink maps the name to a path on the host like resolve, but keeps a symbolic link at the end of the name.
+func (d Dir) resolveLink(name string) (string, error) {
+	if d == "" {
+		return filepath.FromSlash(name), nil
+	}
+	name = path.Clean("/" + name)
+	dir, err := d.resolve(path.Dir(name))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, path.Base(name)), nil
+}
+
+// hide replaces the host path in errors by the name, so the location of the root is not revealed.
+func (d Dir) hide(name string, err error) error {
+	if pathErr, ok := err.(*os.PathError); ok && d != "" {
+		return &os.PathError{Op: pathErr.Op, Path: name, Err: pathErr.Err}
+	}
+	return err
+}
+
+// OpenFile opens the named file using the flags of os.OpenFile.
+func (d Dir) OpenFile(name string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
+	resolved, err := d.resolve(name)
+	if err != nil {
+		return nil, d.hide(name, err)
+	}
+	file, err := os.OpenFile(resolved, flag, perm)
+	if err != nil {
+		return nil, d.hide(name, err)
+	}
+	return file, nil
+}
+
+// Stat returns information about the named file.
+func (d Dir) Stat(name string) (os.FileInfo, error) {
+	resolved, err := d.resolve(name)
+	if err != nil {
+		return nil, d.hide(name, err)
+	}
+	info, err := os.Stat(resolved)
+	return info, d.hide(name, err)
+}
+
+// ReadDir lists the named directory sorted by file name.
+func (d Dir) ReadDir(name string) ([]os.FileInfo, error) {
+	resolved, err := d.resolve(name)
+	if err != nil {
+		return nil, d.hide(name, err)
+	}
+	infos, err := ioutil.ReadDir(resolved)
+	return infos, d.hide(name, err)
+}
+
+// MkdirAll creates the named directory and all missing parents.
+func (d Dir) MkdirAll(name string, perm os.FileMode) error {
+	resolved, err := d.resolve(name)
+	if err != nil {
+		return d.hide(name, err)
+	}
+	return d.hide(name, os.MkdirAll(resolved, perm))
+}
+
+// Remove removes the named file or empty directory, a symbolic link is removed instead of its target.
+func (d Dir) Remove(name string) error {
+	resolved, err := d.resolveLink(name)
+	if err != nil {
+		return d.hide(name, err)
+	}
+	return d.hide(name, os.Remove(resolved))
+}
+
+// host is the unrestricted system of the running process.
+type host struct {
+	once  sync.Once
+	stdin *bufio.Reader
+}
+
+// Stdin returns the buffered standard input of the process.
+func (h *host) Stdin() io.Reader {
+	h.once.Do(func() {
+		h.stdin = bufio.NewReader(os.Stdin)
+	})
+	return h.stdin
+}
+
+func (*host) Stdout() io.Writer {
+	return os.Stdout
+}
+
+func (*host) Stderr() io.Writer {
+	return os.Stderr
+}
+
+func (*host) Files() FileSystem {
+	return Dir("")
+}
+
+func (*host) Getenv(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (*host) Setenv(key, value string) error {
+	return os.Setenv(key, value)
+}
+
+func (*host) Now() time.Time {
+	return time.Now()
+}
+
+func (*host) Exec(ctx context.Context, name string, args []string, stdout, stderr io.Writer) (int, error) {
+	return run(ctx, name, args, stdout, stderr)
+}
+
+// run executes the command on the host, a non-zero exit status is not an error.
+// If the command is killed because the Go context is done, the error of the context is returned.
+func run(ctx context.Context, name string, args []string, stdout, stderr io.Writer) (int, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// Host is the unrestricted system of the running process, it is used by default.
+var Host System = &host{}
+
+// Sandbox is a system granting only the configured capabilities.
+// Missing streams read nothing and discard writes, a nil file system denies file access
+// and the environment is isolated from the host. Commands run on the host if allowed.
+type Sandbox struct {
+	In          io.Reader
+	Out, Err    io.Writer
+	FS          FileSystem
+	Env         map[string]string
+	ReadOnlyEnv bool
+	Clock       func() time.Time
+	AllowExec   bool
+
+	mutex sync.Mutex
+}
+
+// Stdin returns the configured input.
+func (s *Sandbox) Stdin() io.Reader {
+	if s.In == nil {
+		return strings.NewReader("")
+	}
+	return s.In
+}
+
+// Stdout returns the configured output.
+func (s *Sandbox) Stdout() io.Writer {
+	if s.Out == nil {
+		return ioutil.Discard
+	}
+	return s.Out
+}
+
+// Stderr returns the configured error output.
+func (s *Sandbox) Stderr() io.Writer {
+	if s.Err == nil {
+		return ioutil.Discard
+	}
+	return s.Err
+}
+
+// Files returns the configured file system.
+func (s *Sandbox) Files() FileSystem {
+	return s.FS
+}
+
+// Getenv looks up the variable in the isolated environment.
+func (s *Sandbox) Getenv(key string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := s.Env[key]
+	return value, ok
+}
+
+// Setenv changes the variable in the isolated environment unless it is read-only.
+func (s *Sandbox) Setenv(key, value string) error {
+	if s.ReadOnlyEnv {
+		return ErrDenied
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.Env == nil {
+		s.Env = make(map[string]string)
+	}
+	s.Env[key] = value
+	return nil
+}
+
+// Now returns the time of the configured clock, the time of the host if there is none.
+func (s *Sandbox) Now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock()
+}
+
+// Exec runs the command on the host if allowed.
+func (s *Sandbox) Exec(ctx context.Context, name string, args []string, stdout, stderr io.Writer) (int, error) {
+	if !s.AllowExec {
+		return 0, ErrDenied
+	}
+	return run(ctx, name, args, stdout, stderr)
+}
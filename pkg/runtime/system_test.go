@@ -0,0 +1,136 @@
+package runtime
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "tea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	if err := ioutil.WriteFile(filepath.Join(root, "inside.txt"), []byte("tea"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, file, want string
+	}{
+		{"Relative", "inside.txt", filepath.Join(root, "inside.txt")},
+		{"Absolute", "/inside.txt", filepath.Join(root, "inside.txt")},
+		{"Parent", "../../etc/passwd", filepath.Join(root, "etc", "passwd")},
+		{"Nested", "a/../../b", filepath.Join(root, "b")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := Dir(root).resolve(tt.file); err != nil || got != tt.want {
+				t.Errorf("resolve(%q) = %s, %v, want %s", tt.file, got, err, tt.want)
+			}
+		})
+	}
+	if _, err := Dir(root).Stat("/inside.txt"); err != nil {
+		t.Errorf("Stat() error = %v", err)
+	}
+}
+
+func TestDir_Symlinks(t *testing.T) {
+	base, err := ioutil.TempDir("", "tea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(base)
+	root, outside := filepath.Join(base, "root"), filepath.Join(base, "outside")
+	for _, dir := range []string{filepath.Join(root, "dir"), outside} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "dir", "inside.txt"), []byte("tea"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		"escape":   outside,
+		"secret":   filepath.Join(outside, "secret.txt"),
+		"dangling": filepath.Join(outside, "missing.txt"),
+		"relative": filepath.Join("..", "outside"),
+		"inside":   "dir",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Skipf("symbolic links not supported: %v", err)
+		}
+	}
+	fs := Dir(root)
+	denied := []string{"escape/secret.txt", "secret", "dangling", "relative/secret.txt", "escape/new.txt"}
+	for _, name := range denied {
+		if file, err := fs.OpenFile(name, os.O_RDWR|os.O_CREATE, 0644); err == nil {
+			file.Close()
+			t.Errorf("OpenFile(%q) escaped the root", name)
+		}
+	}
+	if _, err := fs.Stat("escape"); err == nil {
+		t.Error("Stat(escape) escaped the root")
+	}
+	if _, err := fs.ReadDir("relative"); err == nil {
+		t.Error("ReadDir(relative) escaped the root")
+	}
+	if err := fs.MkdirAll("escape/new", 0755); err == nil {
+		t.Error("MkdirAll(escape/new) escaped the root")
+	}
+	if err := fs.Remove("escape/secret.txt"); err == nil {
+		t.Error("Remove(escape/secret.txt) escaped the root")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "secret.txt")); err != nil {
+		t.Errorf("file outside of the root has been removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "new.txt")); err == nil {
+		t.Error("file outside of the root has been created")
+	}
+	if _, err := fs.Stat("inside/inside.txt"); err != nil {
+		t.Errorf("Stat(inside/inside.txt) error = %v", err)
+	}
+	if err := fs.Remove("secret"); err != nil {
+		t.Errorf("Remove(secret) error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "secret.txt")); err != nil {
+		t.Errorf("Remove(secret) removed the target of the link: %v", err)
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &Sandbox{Env: map[string]string{"HOME": "/tea"}, Clock: func() time.Time { return now }}
+	if s.Files() != nil {
+		t.Error("Files() expected nil file system")
+	}
+	if v, ok := s.Getenv("HOME"); !ok || v != "/tea" {
+		t.Errorf("Getenv(HOME) = %s, %v", v, ok)
+	}
+	if _, ok := s.Getenv("PATH"); ok {
+		t.Error("Getenv(PATH) expected isolated environment")
+	}
+	if err := s.Setenv("PATH", "/bin"); err != nil {
+		t.Errorf("Setenv() error = %v", err)
+	}
+	s.ReadOnlyEnv = true
+	if err := s.Setenv("PATH", "/usr/bin"); err != ErrDenied {
+		t.Errorf("Setenv() error = %v, want ErrDenied", err)
+	}
+	if !s.Now().Equal(now) {
+		t.Errorf("Now() = %s, want %s", s.Now(), now)
+	}
+	if n, err := s.Stdout().Write([]byte("discarded")); n != 9 || err != nil {
+		t.Errorf("Stdout().Write() = %d, %v", n, err)
+	}
+	if _, err := s.Exec(context.Background(), "true", nil, nil, nil); err != ErrDenied {
+		t.Errorf("Exec() error = %v, want ErrDenied", err)
+	}
+}
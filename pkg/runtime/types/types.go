@@ -2,7 +2,12 @@ package types
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/tealang/core/pkg/runtime"
@@ -13,7 +18,10 @@ var (
 	Any, Bool, Function *runtime.Datatype
 	Integer, Float      *runtime.Datatype
 	String              *runtime.Datatype
-	Array               *runtime.Datatype
+	Array, Map          *runtime.Datatype
+	Error, Regex        *runtime.Datatype
+	Time, Duration      *runtime.Datatype
+	BigInt, Decimal     *runtime.Datatype
 )
 
 // Boolean values.
@@ -82,6 +90,254 @@ func init() {
 			return "array"
 		},
 	}
+	Map = &runtime.Datatype{
+		Name:   "map",
+		Parent: Any,
+		Cast: func(v runtime.Value, f []runtime.Typeflag) (runtime.Value, error) {
+			switch v.Type {
+			case nil:
+				return runtime.Value{
+					Typeflag: v.Typeflag,
+					Data:     nil,
+					Name:     v.Name,
+				}, nil
+			case Map:
+				return runtime.Value{
+					Typeflag: v.Typeflag,
+					Data:     v.Data,
+					Name:     v.Name,
+				}, nil
+			default:
+				return runtime.Value{}, errors.Errorf("can not cast %s to map", v.Type)
+			}
+		},
+		Format: func(v runtime.Value) string {
+			return "map"
+		},
+	}
+	Error = &runtime.Datatype{
+		Name:   "error",
+		Parent: Any,
+		Cast: func(v runtime.Value, f []runtime.Typeflag) (runtime.Value, error) {
+			if len(f) != 0 {
+				return runtime.Value{}, errors.New("unsupported type parameters")
+			}
+			switch v.Type {
+			case nil:
+				return runtime.Value{
+					Typeflag: runtime.T(Error),
+					Data:     nil,
+					Name:     v.Name,
+				}, nil
+			case Error:
+				return v, nil
+			default:
+				return runtime.Value{}, errors.Errorf("can not cast %s to error", v.Type)
+			}
+		},
+		Format: func(v runtime.Value) string {
+			if v.Data == nil {
+				return "error"
+			}
+			return fmt.Sprintf("error: %s", v.Data)
+		},
+	}
+	Regex = &runtime.Datatype{
+		Name:   "regex",
+		Parent: Any,
+		Cast: func(v runtime.Value, f []runtime.Typeflag) (runtime.Value, error) {
+			if len(f) != 0 {
+				return runtime.Value{}, errors.New("unsupported type parameters")
+			}
+			switch v.Type {
+			case nil:
+				return runtime.Value{
+					Typeflag: runtime.T(Regex),
+					Data:     nil,
+					Name:     v.Name,
+				}, nil
+			case Regex:
+				return v, nil
+			case String:
+				re, err := regexp.Compile(v.Data.(string))
+				if err != nil {
+					return runtime.Value{}, errors.Wrap(err, "can not cast string to regex")
+				}
+				return runtime.Value{
+					Typeflag: runtime.T(Regex),
+					Data:     re,
+					Name:     v.Name,
+				}, nil
+			default:
+				return runtime.Value{}, errors.Errorf("can not cast %s to regex", v.Type)
+			}
+		},
+		Format: func(v runtime.Value) string {
+			if v.Data == nil {
+				return "regex"
+			}
+			return v.Data.(*regexp.Regexp).String()
+		},
+	}
+	Time = &runtime.Datatype{
+		Name:   "time",
+		Parent: Any,
+		Cast: func(v runtime.Value, f []runtime.Typeflag) (runtime.Value, error) {
+			if len(f) != 0 {
+				return runtime.Value{}, errors.New("unsupported type parameters")
+			}
+			switch v.Type {
+			case nil:
+				return runtime.Value{
+					Typeflag: runtime.T(Time),
+					Data:     time.Time{},
+					Name:     v.Name,
+				}, nil
+			case Time:
+				return v, nil
+			case String:
+				t, err := time.Parse(time.RFC3339Nano, v.Data.(string))
+				if err != nil {
+					return runtime.Value{}, errors.Wrap(err, "can not cast string to time")
+				}
+				return runtime.Value{
+					Typeflag: runtime.T(Time),
+					Data:     t,
+					Name:     v.Name,
+				}, nil
+			default:
+				return runtime.Value{}, errors.Errorf("can not cast %s to time", v.Type)
+			}
+		},
+		Format: func(v runtime.Value) string {
+			return v.Data.(time.Time).Format(time.RFC3339Nano)
+		},
+	}
+	Duration = &runtime.Datatype{
+		Name:   "duration",
+		Parent: Any,
+		Cast: func(v runtime.Value, f []runtime.Typeflag) (runtime.Value, error) {
+			if len(f) != 0 {
+				return runtime.Value{}, errors.New("unsupported type parameters")
+			}
+			switch v.Type {
+			case nil:
+				return runtime.Value{
+					Typeflag: runtime.T(Duration),
+					Data:     time.Duration(0),
+					Name:     v.Name,
+				}, nil
+			case Duration:
+				return v, nil
+			case String:
+				d, err := time.ParseDuration(v.Data.(string))
+				if err != nil {
+					return runtime.Value{}, errors.Wrap(err, "can not cast string to duration")
+				}
+				return runtime.Value{
+					Typeflag: runtime.T(Duration),
+					Data:     d,
+					Name:     v.Name,
+				}, nil
+			default:
+				return runtime.Value{}, errors.Errorf("can not cast %s to duration", v.Type)
+			}
+		},
+		Format: func(v runtime.Value) string {
+			return v.Data.(time.Duration).String()
+		},
+	}
+	BigInt = &runtime.Datatype{
+		Name:   "bigint",
+		Parent: Any,
+		Format: func(v runtime.Value) string {
+			if v.Data == nil {
+				return "null"
+			}
+			return fmt.Sprint(v.Data)
+		},
+		Cast: func(v runtime.Value, f []runtime.Typeflag) (runtime.Value, error) {
+			if len(f) != 0 {
+				return runtime.Value{}, errors.New("unsupported type parameters")
+			}
+			if isNull(v) {
+				return runtime.Value{Typeflag: runtime.T(BigInt), Name: v.Name}, nil
+			}
+			i := new(big.Int)
+			switch v.Type {
+			case BigInt:
+				return v, nil
+			case Integer:
+				i.SetInt64(v.Data.(int64))
+			case Float:
+				f := v.Data.(float64)
+				if math.IsInf(f, 0) || math.IsNaN(f) {
+					return runtime.Value{}, errors.Errorf("can not cast %v to bigint", f)
+				}
+				big.NewFloat(f).Int(i)
+			case Decimal:
+				r := v.Data.(*Rational).Rat()
+				i.Quo(r.Num(), r.Denom())
+			case String:
+				if _, ok := i.SetString(v.Data.(string), 10); !ok {
+					return runtime.Value{}, errors.Errorf("can not cast string %q to bigint", v.Data)
+				}
+			default:
+				return runtime.Value{}, errors.Errorf("can not cast %s to bigint", v.Type)
+			}
+			return runtime.Value{
+				Typeflag: runtime.T(BigInt),
+				Data:     i,
+				Name:     v.Name,
+			}, nil
+		},
+	}
+	Decimal = &runtime.Datatype{
+		Name:   "decimal",
+		Parent: Any,
+		Format: func(v runtime.Value) string {
+			if v.Data == nil {
+				return "null"
+			}
+			return fmt.Sprint(v.Data)
+		},
+		Cast: func(v runtime.Value, f []runtime.Typeflag) (runtime.Value, error) {
+			if len(f) != 0 {
+				return runtime.Value{}, errors.New("unsupported type parameters")
+			}
+			if isNull(v) {
+				return runtime.Value{Typeflag: runtime.T(Decimal), Name: v.Name}, nil
+			}
+			r := new(big.Rat)
+			switch v.Type {
+			case Decimal:
+				return v, nil
+			case Integer:
+				r.SetInt64(v.Data.(int64))
+			case BigInt:
+				r.SetInt(v.Data.(*big.Int))
+			case Float:
+				// use the shortest representation, so 0.1 becomes exactly 1/10
+				f := v.Data.(float64)
+				if math.IsInf(f, 0) || math.IsNaN(f) {
+					return runtime.Value{}, errors.Errorf("can not cast %v to decimal", f)
+				}
+				r.SetString(strconv.FormatFloat(f, 'g', -1, 64))
+			case String:
+				text := v.Data.(string)
+				if _, ok := r.SetString(text); !ok || strings.ContainsRune(text, '/') {
+					return runtime.Value{}, errors.Errorf("can not cast string %q to decimal", text)
+				}
+			default:
+				return runtime.Value{}, errors.Errorf("can not cast %s to decimal", v.Type)
+			}
+			return runtime.Value{
+				Typeflag: runtime.T(Decimal),
+				Data:     (*Rational)(r),
+				Name:     v.Name,
+			}, nil
+		},
+	}
 	Integer = &runtime.Datatype{
 		Name:   "int",
 		Parent: Any,
@@ -117,6 +373,24 @@ func init() {
 					Data:     i,
 					Name:     v.Name,
 				}, nil
+			case BigInt, Decimal:
+				i, err := BigInt.Cast(v, nil)
+				if err != nil {
+					return runtime.Value{}, err
+				}
+				// null numbers are cast like null
+				n, _ := i.Data.(*big.Int)
+				if n == nil {
+					n = new(big.Int)
+				}
+				if !n.IsInt64() {
+					return runtime.Value{}, errors.Errorf("can not cast %s to int: %s overflows", v.Type, n)
+				}
+				return runtime.Value{
+					Typeflag: runtime.T(Integer),
+					Data:     n.Int64(),
+					Name:     v.Name,
+				}, nil
 			default:
 				return runtime.Value{}, errors.Errorf("can not cast %s to int", v.Type)
 			}
@@ -147,6 +421,21 @@ func init() {
 				}, nil
 			case Float:
 				return v, nil
+			case BigInt, Decimal:
+				// null numbers are cast like null
+				r, err := Decimal.Cast(v, nil)
+				if err != nil {
+					return runtime.Value{}, err
+				}
+				var f float64
+				if r.Data != nil {
+					f, _ = r.Data.(*Rational).Rat().Float64()
+				}
+				return runtime.Value{
+					Typeflag: runtime.T(Float),
+					Data:     f,
+					Name:     v.Name,
+				}, nil
 			default:
 				return runtime.Value{}, errors.Errorf("can not cast %s to float", v.Type)
 			}
@@ -171,6 +460,12 @@ func init() {
 				}, nil
 			case String:
 				return v, nil
+			case BigInt, Decimal:
+				return runtime.Value{
+					Typeflag: runtime.T(String),
+					Data:     v.Type.Format(v),
+					Name:     v.Name,
+				}, nil
 			default:
 				return runtime.Value{}, errors.Errorf("can not cast %s to string", v.Type)
 			}
@@ -230,6 +525,77 @@ func init() {
 	}
 }
 
+// decimalPlaces is the number of fractional digits decimals without finite decimal representation are rounded to.
+const decimalPlaces = 20
+
+// Rational is the data of decimal values, an exact rational number formatted in decimal notation.
+type Rational big.Rat
+
+// Rat returns the rational number.
+func (r *Rational) Rat() *big.Rat {
+	return (*big.Rat)(r)
+}
+
+func (r *Rational) String() string {
+	rat := r.Rat()
+	if rat.IsInt() {
+		return rat.Num().String()
+	}
+	// the decimal representation is finite if the denominator has no prime factors besides 2 and 5
+	var (
+		denom    = new(big.Int).Set(rat.Denom())
+		places   int
+		quo, rem = new(big.Int), new(big.Int)
+	)
+	for _, factor := range []*big.Int{big.NewInt(2), big.NewInt(5)} {
+		count := 0
+		for {
+			quo.QuoRem(denom, factor, rem)
+			if rem.Sign() != 0 {
+				break
+			}
+			denom.Set(quo)
+			count++
+		}
+		if count > places {
+			places = count
+		}
+	}
+	if denom.IsInt64() && denom.Int64() == 1 {
+		return rat.FloatString(places)
+	}
+	return strings.TrimRight(strings.TrimRight(rat.FloatString(decimalPlaces), "0"), ".")
+}
+
+// isNull checks if the value is null, bigints and decimals may be null while keeping their datatype.
+func isNull(v runtime.Value) bool {
+	return v.Type == nil || v.Data == nil && (v.Type == BigInt || v.Type == Decimal)
+}
+
+// NewDecimal constructs a decimal value of the rational number.
+func NewDecimal(r *big.Rat) runtime.Value {
+	return runtime.Value{
+		Typeflag: runtime.T(Decimal),
+		Data:     (*Rational)(r),
+	}
+}
+
+// Underlying removes the any type wrapped around values cast to any, e.g. function arguments of type any.
+func Underlying(v runtime.Value) runtime.Value {
+	for v.Type == Any && len(v.Params) == 1 {
+		v.Typeflag = v.Params[0]
+	}
+	return v
+}
+
+// NewError constructs an error value, builtins return them to report failures to the program.
+func NewError(err error) runtime.Value {
+	return runtime.Value{
+		Typeflag: runtime.T(Error),
+		Data:     err,
+	}
+}
+
 func Load(ctx *runtime.Context) {
 	ctx.Namespace.Store(Any)
 	ctx.Namespace.Store(Function)
@@ -238,4 +604,11 @@ func Load(ctx *runtime.Context) {
 	ctx.Namespace.Store(Integer)
 	ctx.Namespace.Store(Float)
 	ctx.Namespace.Store(Array)
+	ctx.Namespace.Store(Map)
+	ctx.Namespace.Store(Error)
+	ctx.Namespace.Store(Regex)
+	ctx.Namespace.Store(Time)
+	ctx.Namespace.Store(Duration)
+	ctx.Namespace.Store(BigInt)
+	ctx.Namespace.Store(Decimal)
 }
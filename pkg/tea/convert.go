@@ -0,0 +1,157 @@
+package tea
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/marshal"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+var (
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	funcType  = reflect.TypeOf(Func{})
+)
+
+// typeflagOf maps a Go type to the Tea typeflag of its converted values.
+func typeflagOf(t reflect.Type) (runtime.Typeflag, error) {
+	if t == funcType {
+		return runtime.T(types.Function), nil
+	}
+	return marshal.Typeflag(t)
+}
+
+// converter returns the marshalling converter, wrapping functions for the interpreter.
+func (in *Interpreter) converter() marshal.Converter {
+	return marshal.Converter{
+		Wrap: func(fn reflect.Value) (runtime.Value, error) {
+			function, err := in.wrap(fn)
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			return runtime.Value{Typeflag: runtime.T(types.Function), Data: function}, nil
+		},
+		Unwrap: func(value runtime.Value, t reflect.Type) (reflect.Value, error) {
+			fn := Func{name: value.Name, function: value.Data.(runtime.Function), interpreter: in}
+			switch {
+			case t == funcType, t.Kind() == reflect.Interface && funcType.Implements(t):
+				return reflect.ValueOf(fn).Convert(t), nil
+			case t.Kind() == reflect.Func:
+				return in.unwrap(fn, t)
+			}
+			return reflect.Value{}, errors.Errorf("can not convert func to %s", t)
+		},
+	}
+}
+
+// toValue converts a Go value into a Tea value, Go functions are wrapped into Tea functions.
+func (in *Interpreter) toValue(v interface{}) (runtime.Value, error) {
+	return in.converter().ToValue(v)
+}
+
+// fromValue converts a Tea value into a Go value of the given type.
+func (in *Interpreter) fromValue(value runtime.Value, t reflect.Type) (reflect.Value, error) {
+	return in.converter().Convert(value, t)
+}
+
+// export converts a Tea value into its natural Go representation.
+// Functions are returned as Func values, arrays as []interface{} and maps as map[string]interface{}.
+func (in *Interpreter) export(value runtime.Value) (interface{}, error) {
+	return in.converter().Export(value)
+}
+
+// wrap generates a Tea function calling the Go function.
+// The Go function may return a single value, an error or a value and an error.
+func (in *Interpreter) wrap(fn reflect.Value) (runtime.Function, error) {
+	t := fn.Type()
+	if t.IsVariadic() {
+		return runtime.Function{}, errors.New("variadic functions are not supported")
+	}
+	expected := make([]runtime.Value, t.NumIn())
+	for i := range expected {
+		typeflag, err := typeflagOf(t.In(i))
+		if err != nil {
+			return runtime.Function{}, errors.Wrapf(err, "parameter %d", i)
+		}
+		expected[i] = runtime.Value{Name: fmt.Sprintf("arg%d", i), Typeflag: typeflag}
+	}
+	returns := runtime.Value{}
+	switch {
+	case t.NumOut() > 2,
+		t.NumOut() == 2 && t.Out(1) != errorType:
+		return runtime.Function{}, errors.New("expected function returning a value, an error or both")
+	case t.NumOut() > 0 && t.Out(0) != errorType:
+		typeflag, err := typeflagOf(t.Out(0))
+		if err != nil {
+			return runtime.Function{}, errors.Wrap(err, "return value")
+		}
+		if typeflag.Type != types.Any {
+			returns.Typeflag = typeflag
+		}
+	}
+	adapter := nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+		args := make([]reflect.Value, len(expected))
+		for i, param := range expected {
+			item, err := c.Namespace.Find(runtime.SearchIdentifier, param.Name)
+			if err != nil {
+				return runtime.Value{}, err
+			}
+			args[i], err = in.fromValue(item.(runtime.Value), t.In(i))
+			if err != nil {
+				return runtime.Value{}, errors.Wrapf(err, "argument %d", i)
+			}
+		}
+		results := fn.Call(args)
+		if n := len(results); n > 0 && t.Out(n-1) == errorType {
+			if err, _ := results[n-1].Interface().(error); err != nil {
+				return runtime.Value{}, err
+			}
+			results = results[:n-1]
+		}
+		if len(results) == 0 {
+			return runtime.Value{}, nil
+		}
+		return in.toValue(results[0].Interface())
+	})
+	return runtime.NewFunction(nil, runtime.NewSignature(returns, adapter, expected)), nil
+}
+
+// unwrap generates a Go function of the given type calling the Tea function.
+// Errors are returned if the Go function returns an error as its last value, otherwise they cause a panic.
+func (in *Interpreter) unwrap(fn Func, t reflect.Type) (reflect.Value, error) {
+	fails := t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType
+	returns := t.NumOut()
+	if fails {
+		returns--
+	}
+	if returns > 1 {
+		return reflect.Value{}, errors.Errorf("can not convert function to %s: expected at most one result", t)
+	}
+	return reflect.MakeFunc(t, func(args []reflect.Value) []reflect.Value {
+		values := make([]interface{}, len(args))
+		for i, arg := range args {
+			values[i] = arg.Interface()
+		}
+		results := make([]reflect.Value, t.NumOut())
+		for i := range results {
+			results[i] = reflect.Zero(t.Out(i))
+		}
+		result, err := fn.Call(values...)
+		if err == nil && returns > 0 {
+			var value runtime.Value
+			if value, err = in.toValue(result); err == nil {
+				results[0], err = in.fromValue(value, t.Out(0))
+			}
+		}
+		if err != nil {
+			if !fails {
+				panic(err)
+			}
+			results[len(results)-1] = reflect.ValueOf(&err).Elem()
+		}
+		return results
+	}), nil
+}
@@ -0,0 +1,167 @@
+// Package tea provides an API for embedding the Tealang interpreter in Go applications.
+//
+// Go values are converted to Tea values and back automatically using the marshal package:
+// booleans map to bool, integers to int, floats to float, strings to string,
+// slices to array, maps and structs to map and functions to func.
+// Go functions are wrapped into Tea functions by reflecting their signature.
+// Tea functions are returned as Func values callable from Go and can be passed to Go function
+// parameters of type Func or of any supported func type.
+package tea
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/tealang/core/pkg/lexer"
+	"github.com/tealang/core/pkg/parser"
+	"github.com/tealang/core/pkg/runtime"
+	"github.com/tealang/core/pkg/runtime/functions"
+	"github.com/tealang/core/pkg/runtime/nodes"
+	"github.com/tealang/core/pkg/runtime/operators"
+	"github.com/tealang/core/pkg/runtime/types"
+)
+
+// Interpreter is a Tea runtime embedded in a Go application.
+type Interpreter struct {
+	context *runtime.Context
+}
+
+// Context returns the runtime context of the interpreter, e.g. to configure limits or tracers.
+func (in *Interpreter) Context() *runtime.Context {
+	return in.context
+}
+
+// Eval runs the Tea program and returns its result converted to a Go value.
+func (in *Interpreter) Eval(code string) (interface{}, error) {
+	return in.EvalContext(context.Background(), code)
+}
+
+// EvalContext runs the Tea program until it completes or the Go context is done.
+func (in *Interpreter) EvalContext(ctx context.Context, code string) (interface{}, error) {
+	ast, _, err := parser.Parse(lexer.Lex(code))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse")
+	}
+	in.context.Reset()
+	value, err := in.context.Eval(ctx, nodes.NewAdapter(func(c *runtime.Context) (runtime.Value, error) {
+		return nodes.Evaluate(c, ast)
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return in.export(value)
+}
+
+// Set defines the global variable with the converted Go value, replacing any existing variable.
+func (in *Interpreter) Set(name string, v interface{}) error {
+	value, err := in.toValue(v)
+	if err != nil {
+		return errors.Wrapf(err, "can not set %s", name)
+	}
+	value.Name = name
+	in.context.GlobalNamespace.Storage[runtime.SearchIdentifier][name] = value
+	return nil
+}
+
+// Get looks up the global variable and returns its value converted to a Go value.
+func (in *Interpreter) Get(name string) (interface{}, error) {
+	item, err := in.context.GlobalNamespace.Find(runtime.SearchIdentifier, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can not get %s", name)
+	}
+	return in.export(item.(runtime.Value))
+}
+
+// GetInto looks up the global variable and stores its value in the Go value pointed to by target.
+// Tea maps can be stored in structs, their fields are matched using the tea struct tag.
+func (in *Interpreter) GetInto(name string, target interface{}) error {
+	item, err := in.context.GlobalNamespace.Find(runtime.SearchIdentifier, name)
+	if err != nil {
+		return errors.Wrapf(err, "can not get %s", name)
+	}
+	if err := in.converter().FromValue(item.(runtime.Value), target); err != nil {
+		return errors.Wrapf(err, "can not get %s", name)
+	}
+	return nil
+}
+
+// Call calls the global function with the converted Go arguments.
+func (in *Interpreter) Call(name string, args ...interface{}) (interface{}, error) {
+	v, err := in.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := v.(Func)
+	if !ok {
+		return nil, errors.Errorf("%s is not a function", name)
+	}
+	return fn.Call(args...)
+}
+
+// RegisterFunc defines a constant global function calling the Go function.
+// The Go function may return nothing, a value, an error or a value and an error.
+// Returned errors cause the Tea function call to fail.
+func (in *Interpreter) RegisterFunc(name string, fn interface{}) error {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func {
+		return errors.Errorf("can not register %s: expected function, got %T", name, fn)
+	}
+	function, err := in.wrap(rv)
+	if err != nil {
+		return errors.Wrapf(err, "can not register %s", name)
+	}
+	value := runtime.Value{
+		Typeflag: runtime.T(types.Function),
+		Data:     function,
+		Name:     name,
+		Constant: true,
+	}
+	if err := in.context.GlobalNamespace.Store(value); err != nil {
+		return errors.Wrapf(err, "can not register %s", name)
+	}
+	return nil
+}
+
+// Func is a Tea function returned to Go.
+type Func struct {
+	name        string
+	function    runtime.Function
+	interpreter *Interpreter
+}
+
+// Call calls the Tea function with the converted Go arguments and returns the converted result.
+func (f Func) Call(args ...interface{}) (interface{}, error) {
+	values := make([]runtime.Value, len(args))
+	for i, arg := range args {
+		value, err := f.interpreter.toValue(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "argument %d", i)
+		}
+		values[i] = value
+	}
+	result, err := f.function.Call(f.interpreter.context, runtime.Frame{Function: f.name}, values)
+	if err != nil {
+		return nil, err
+	}
+	return f.interpreter.export(result)
+}
+
+// MarshalTea converts the function back into a Tea function value.
+func (f Func) MarshalTea() (runtime.Value, error) {
+	return runtime.Value{Typeflag: runtime.T(types.Function), Data: f.function}, nil
+}
+
+// String returns the signatures of the function.
+func (f Func) String() string {
+	return f.function.String()
+}
+
+// New constructs a new interpreter with the language runtime loaded.
+func New() *Interpreter {
+	c := runtime.NewContext()
+	operators.Load(c)
+	types.Load(c)
+	functions.Load(c)
+	return &Interpreter{context: c}
+}
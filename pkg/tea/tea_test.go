@@ -0,0 +1,226 @@
+package tea
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestInterpreter_Eval(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want interface{}
+	}{
+		{"Integer", "1 + 2;", int64(3)},
+		{"Float", "1.5 * 2.0;", 3.0},
+		{"String", `"tea" + "pot";`, "teapot"},
+		{"Bool", "1 < 2;", true},
+		{"Null", "let x = 1;", int64(1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().Eval(tt.code)
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Eval() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpreter_SetGet(t *testing.T) {
+	in := New()
+	if err := in.Set("x", 20); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Set("name", "tea"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := in.Eval(`x = x + 1; name = name + "pot";`); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := in.Get("x"); got != int64(21) {
+		t.Errorf("Get(x) = %#v, want 21", got)
+	}
+	if got, _ := in.Get("name"); got != "teapot" {
+		t.Errorf("Get(name) = %#v, want teapot", got)
+	}
+	if _, err := in.Get("missing"); err == nil {
+		t.Error("Get(missing) expected error")
+	}
+	if err := in.Set("c", make(chan int)); err == nil {
+		t.Error("Set(chan) expected error")
+	}
+}
+
+func TestInterpreter_RegisterFunc(t *testing.T) {
+	in := New()
+	if err := in.RegisterFunc("add", func(a, b int) int { return a + b }); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.RegisterFunc("fail", func(s string) (string, error) { return "", errors.New(s) }); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := in.Eval("add(2, 3);"); err != nil || got != int64(5) {
+		t.Errorf("add(2, 3) = %#v, %v, want 5", got, err)
+	}
+	if _, err := in.Eval(`add(2, "3");`); err == nil {
+		t.Error(`add(2, "3") expected error`)
+	}
+	if _, err := in.Eval(`fail("boom");`); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf(`fail("boom") error = %v, want boom`, err)
+	}
+	if err := in.RegisterFunc("add", func() {}); err == nil {
+		t.Error("RegisterFunc() twice expected error")
+	}
+	if err := in.RegisterFunc("sum", func(xs ...int) {}); err == nil {
+		t.Error("RegisterFunc() variadic expected error")
+	}
+	if err := in.RegisterFunc("one", 1); err == nil {
+		t.Error("RegisterFunc() non-function expected error")
+	}
+}
+
+func TestInterpreter_Call(t *testing.T) {
+	in := New()
+	if _, err := in.Eval("func double(x: int): int { return x * 2; }"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := in.Call("double", 21); err != nil || got != int64(42) {
+		t.Errorf("Call(double, 21) = %#v, %v, want 42", got, err)
+	}
+	if _, err := in.Call("double", "x"); err == nil {
+		t.Error("Call(double, x) expected error")
+	}
+
+	// pass a Tea function into Go and call it from there
+	if err := in.RegisterFunc("apply", func(f Func, x int) (interface{}, error) { return f.Call(x) }); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := in.Eval("apply(double, 4);"); err != nil || got != int64(8) {
+		t.Errorf("apply(double, 4) = %#v, %v, want 8", got, err)
+	}
+}
+
+func TestInterpreter_Callback(t *testing.T) {
+	in := New()
+	if _, err := in.Eval("func inc(x: int): int { return x + 1; }"); err != nil {
+		t.Fatal(err)
+	}
+	var twice func(int) (int, error)
+	if err := in.RegisterFunc("twice", func(f func(int) (int, error), x int) (int, error) {
+		twice = f
+		y, err := f(x)
+		if err != nil {
+			return 0, err
+		}
+		return f(y)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := in.Eval("twice(inc, 1);"); err != nil || got != int64(3) {
+		t.Errorf("twice(inc, 1) = %#v, %v, want 3", got, err)
+	}
+	if got, err := twice(10); err != nil || got != 11 {
+		t.Errorf("f(10) = %v, %v, want 11", got, err)
+	}
+}
+
+func TestInterpreter_Marshal(t *testing.T) {
+	type config struct {
+		Name  string   `tea:"name"`
+		Ports []int    `tea:"ports"`
+		Debug bool     `tea:"debug,omitempty"`
+		Tags  []string `tea:"-"`
+	}
+	in := New()
+	if err := in.Set("config", config{Name: "tea", Ports: []int{80, 443}, Tags: []string{"x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.RegisterFunc("total", func(ports []int) int {
+		sum := 0
+		for _, p := range ports {
+			sum += p
+		}
+		return sum
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.RegisterFunc("rename", func(c config, name string) config {
+		c.Name = name
+		return c
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := in.Eval(`var renamed = rename(config, "pot");`); err != nil {
+		t.Fatal(err)
+	}
+	var got config
+	if err := in.GetInto("renamed", &got); err != nil {
+		t.Fatal(err)
+	}
+	want := config{Name: "pot", Ports: []int{80, 443}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetInto() = %#v, want %#v", got, want)
+	}
+	exported, err := in.Get("renamed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := exported.(map[string]interface{}); !ok || m["name"] != "pot" {
+		t.Errorf("Get() = %#v, want map with name pot", exported)
+	}
+	if got, err := in.Call("total", []int{1, 2, 3}); err != nil || got != int64(6) {
+		t.Errorf("total([1, 2, 3]) = %#v, %v, want 6", got, err)
+	}
+}
+
+func TestInterpreter_Time(t *testing.T) {
+	in := New()
+	start := time.Unix(0, 0).UTC()
+	if err := in.Set("start", start); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Set("step", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	got, err := in.Eval("start + step;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if end, ok := got.(time.Time); !ok || !end.Equal(start.Add(time.Minute)) {
+		t.Errorf("start + step = %#v, want %s", got, start.Add(time.Minute))
+	}
+	var step time.Duration
+	if err := in.GetInto("step", &step); err != nil || step != time.Minute {
+		t.Errorf("GetInto(step) = %s, %v, want %s", step, err, time.Minute)
+	}
+}
+
+func TestInterpreter_Big(t *testing.T) {
+	in := New()
+	if err := in.Set("n", big.NewInt(5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := in.Set("r", big.NewRat(1, 4)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := in.Eval("n * 2n;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := got.(*big.Int); !ok || n.Int64() != 10 {
+		t.Errorf("n * 2n = %#v, want 10", got)
+	}
+	var r *big.Rat
+	if err := in.GetInto("r", &r); err != nil || r.Cmp(big.NewRat(1, 4)) != 0 {
+		t.Errorf("GetInto(r) = %v, %v, want 1/4", r, err)
+	}
+}